@@ -0,0 +1,110 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+)
+
+// HeightBuilder builds the envelopes of actions to be included at a height, with the chain ID and the gas price filled
+// per the fork rules of the genesis at the height, so that they are not rejected for a wrong chain ID once Quebec
+// enforces it
+type HeightBuilder struct {
+	g        *genesis.Blockchain
+	chainID  uint32
+	gasLimit uint64
+	gasPrice *big.Int
+}
+
+// NewHeightBuilder returns an action builder for the height with the gas limit, which is checked against the action
+// gas limit of the genesis when building. The chain ID is required since Midway, and the gas price defaults to the
+// minimum gas price.
+func NewHeightBuilder(g *genesis.Genesis, height uint64, gasLimit uint64) *HeightBuilder {
+	b := &HeightBuilder{
+		g:        &g.Blockchain,
+		gasLimit: gasLimit,
+		gasPrice: g.MinGasPrice(),
+	}
+	if g.IsMidway(height) {
+		b.chainID = g.ChainID
+	}
+	return b
+}
+
+// ChainID returns the chain ID of the actions
+func (b *HeightBuilder) ChainID() uint32 {
+	return b.chainID
+}
+
+// GasLimit returns the gas limit of the actions
+func (b *HeightBuilder) GasLimit() uint64 {
+	return b.gasLimit
+}
+
+// GasPrice returns the gas price of the actions
+func (b *HeightBuilder) GasPrice() *big.Int {
+	return new(big.Int).Set(b.gasPrice)
+}
+
+// SetGasLimit sets the gas limit of the actions, which is checked against the action gas limit when building
+func (b *HeightBuilder) SetGasLimit(l uint64) *HeightBuilder {
+	b.gasLimit = l
+	return b
+}
+
+// SetGasPrice sets the gas price of the actions
+func (b *HeightBuilder) SetGasPrice(p *big.Int) *HeightBuilder {
+	if p == nil {
+		return b
+	}
+	b.gasPrice = new(big.Int).Set(p)
+	return b
+}
+
+// Transfer builds the envelope of a transfer of amount to the recipient
+func (b *HeightBuilder) Transfer(nonce uint64, amount *big.Int, recipient string, payload []byte) (Envelope, error) {
+	tsf, err := NewTransfer(nonce, amount, recipient, payload, b.gasLimit, b.GasPrice())
+	if err != nil {
+		return nil, err
+	}
+	return b.build(nonce, tsf)
+}
+
+// Execution builds the envelope of an execution calling the contract with amount and data, an empty contract address
+// deploys a new contract
+func (b *HeightBuilder) Execution(nonce uint64, contract string, amount *big.Int, data []byte) (Envelope, error) {
+	exec, err := NewExecution(contract, nonce, amount, b.gasLimit, b.GasPrice(), data)
+	if err != nil {
+		return nil, err
+	}
+	return b.build(nonce, exec)
+}
+
+// ClaimFromRewardingFund builds the envelope of claiming amount from the rewarding fund
+func (b *HeightBuilder) ClaimFromRewardingFund(nonce uint64, amount *big.Int, data []byte) (Envelope, error) {
+	claim := (&ClaimFromRewardingFundBuilder{}).SetAmount(amount).SetData(data).Build()
+	return b.build(nonce, &claim)
+}
+
+func (b *HeightBuilder) build(nonce uint64, payload actionPayload) (Envelope, error) {
+	if b.gasLimit == 0 {
+		return nil, errors.New("gas limit is not set")
+	}
+	if err := b.g.CheckActionGas(b.gasLimit); err != nil {
+		return nil, err
+	}
+	return (&EnvelopeBuilder{}).
+		SetNonce(nonce).
+		SetGasLimit(b.gasLimit).
+		SetGasPrice(b.gasPrice).
+		SetChainID(b.chainID).
+		SetAction(payload).
+		Build(), nil
+}
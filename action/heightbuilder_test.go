@@ -0,0 +1,69 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestHeightBuilder(t *testing.T) {
+	require := require.New(t)
+	g := genesis.GetDefault()
+	for _, v := range []struct {
+		height  uint64
+		chainID uint32
+	}{
+		{g.MidwayBlockHeight - 1, 0},
+		{g.MidwayBlockHeight, g.ChainID},
+		{g.QuebecBlockHeight, g.ChainID},
+	} {
+		b := NewHeightBuilder(&g, v.height, 20000)
+		require.Equal(v.chainID, b.ChainID())
+		require.EqualValues(20000, b.GasLimit())
+		require.Equal(g.MinGasPrice(), b.GasPrice())
+
+		recipient := identityset.Address(29).String()
+		elp, err := b.Transfer(1, big.NewInt(10), recipient, nil)
+		require.NoError(err)
+		require.True(g.IsValidChainID(elp.ChainID(), v.height))
+		require.Equal(v.chainID, elp.ChainID())
+		require.EqualValues(1, elp.Nonce())
+		require.EqualValues(20000, elp.GasLimit())
+		require.Equal(g.MinGasPrice(), elp.GasPrice())
+		tsf, ok := elp.Action().(*Transfer)
+		require.True(ok)
+		require.Equal(recipient, tsf.Recipient())
+		require.Equal(big.NewInt(10), tsf.Amount())
+	}
+
+	b := NewHeightBuilder(&g, g.QuebecBlockHeight, 20000).SetGasLimit(21000).SetGasPrice(new(big.Int).Mul(big.NewInt(2), big.NewInt(unit.Qev)))
+	elp, err := b.Execution(2, "", big.NewInt(0), []byte{0x60, 0x80})
+	require.NoError(err)
+	require.EqualValues(21000, elp.GasLimit())
+	require.Equal(new(big.Int).Mul(big.NewInt(2), big.NewInt(unit.Qev)), elp.GasPrice())
+	exec, ok := elp.Action().(*Execution)
+	require.True(ok)
+	require.Equal([]byte{0x60, 0x80}, exec.Data())
+
+	elp, err = b.ClaimFromRewardingFund(3, big.NewInt(100), nil)
+	require.NoError(err)
+	claim, ok := elp.Action().(*ClaimFromRewardingFund)
+	require.True(ok)
+	require.Equal(big.NewInt(100), claim.Amount())
+
+	// the gas limit is bounded by the action gas limit
+	_, err = b.SetGasLimit(g.ActionGasLimit+1).Transfer(4, big.NewInt(10), identityset.Address(29).String(), nil)
+	require.ErrorContains(err, "exceeds action gas limit")
+	_, err = b.SetGasLimit(0).Transfer(4, big.NewInt(10), identityset.Address(29).String(), nil)
+	require.ErrorContains(err, "gas limit is not set")
+}
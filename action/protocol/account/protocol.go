@@ -140,23 +140,16 @@ func (p *Protocol) CreateGenesisStates(ctx context.Context, sm protocol.StateMan
 	if err := p.assertZeroBlockHeight(blkCtx.BlockHeight); err != nil {
 		return err
 	}
-	addrs, amounts := g.InitBalances()
-	if err := p.assertEqualLength(addrs, amounts); err != nil {
-		return err
-	}
-	if err := p.assertAmounts(amounts); err != nil {
-		return err
-	}
 	opts := []state.AccountCreationOption{}
 	if protocol.MustGetFeatureCtx(ctx).CreateLegacyNonceAccount {
 		opts = append(opts, state.LegacyNonceAccountTypeOption())
 	}
-	for i, addr := range addrs {
-		if err := createAccount(sm, addr.String(), amounts[i], opts...); err != nil {
+	return g.EachInitBalance(func(addr address.Address, amount *big.Int) error {
+		if err := p.assertAmounts([]*big.Int{amount}); err != nil {
 			return err
 		}
-	}
-	return nil
+		return createAccount(sm, addr.String(), amount, opts...)
+	})
 }
 
 func (p *Protocol) assertZeroBlockHeight(height uint64) error {
@@ -166,17 +159,6 @@ func (p *Protocol) assertZeroBlockHeight(height uint64) error {
 	return nil
 }
 
-func (p *Protocol) assertEqualLength(addrs []address.Address, amounts []*big.Int) error {
-	if len(addrs) != len(amounts) {
-		return errors.Errorf(
-			"address slice length %d and amounts slice length %d don't match",
-			len(addrs),
-			len(amounts),
-		)
-	}
-	return nil
-}
-
 func (p *Protocol) assertAmounts(amounts []*big.Int) error {
 	for _, amount := range amounts {
 		if amount.Cmp(big.NewInt(0)) < 0 {
@@ -191,7 +191,7 @@ func TestAssertZeroBlockHeight(t *testing.T) {
 	require.Error(p.assertZeroBlockHeight(1))
 }
 
-func TestAssertAmountsAndEqualLength(t *testing.T) {
+func TestAssertAmounts(t *testing.T) {
 	require := require.New(t)
 
 	p := NewProtocol(rewarding.DepositGas)
@@ -203,13 +203,4 @@ func TestAssertAmountsAndEqualLength(t *testing.T) {
 	require.NoError(p.assertAmounts(amounts))
 	amounts[0] = big.NewInt(-1)
 	require.Error(p.assertAmounts(amounts))
-
-	addrs := []address.Address{
-		&address.AddrV1{},
-		&address.AddrV1{},
-		&address.AddrV1{},
-	}
-	require.NoError(p.assertEqualLength(addrs, amounts))
-	addrs = addrs[:2]
-	require.Error(p.assertEqualLength(addrs, amounts))
 }
@@ -7,8 +7,10 @@ package accountutil
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
@@ -112,3 +114,55 @@ func AccountStateWithHeight(ctx context.Context, sr protocol.StateReader, addr a
 		return nil, h, errors.Wrapf(err, "error when loading state of %x", pkHash)
 	}
 }
+
+// BalancesConcurrent reads the balances of the addresses with at most workers concurrent reads, and returns them in
+// the order of addrs. The balance of an account not recorded on the chain is zero. All the reads are cancelled once
+// any of them fails or ctx is done.
+func BalancesConcurrent(ctx context.Context, sr protocol.StateReader, addrs []string, workers int) ([]*big.Int, error) {
+	if workers <= 0 {
+		return nil, errors.Errorf("invalid number of workers %d", workers)
+	}
+	var (
+		balances  = make([]*big.Int, len(addrs))
+		indices   = make(chan int)
+		eg, egCtx = errgroup.WithContext(ctx)
+	)
+	eg.Go(func() error {
+		defer close(indices)
+		for i := range addrs {
+			select {
+			case indices <- i:
+			case <-egCtx.Done():
+				return egCtx.Err()
+			}
+		}
+		return nil
+	})
+	for w := 0; w < workers; w++ {
+		eg.Go(func() error {
+			for i := range indices {
+				if err := egCtx.Err(); err != nil {
+					return err
+				}
+				addr, err := address.FromString(addrs[i])
+				if err != nil {
+					return errors.Wrapf(err, "invalid address %s", addrs[i])
+				}
+				account := &state.Account{}
+				switch _, err := sr.State(account, protocol.LegacyKeyOption(hash.BytesToHash160(addr.Bytes()))); errors.Cause(err) {
+				case nil:
+					balances[i] = account.Balance
+				case state.ErrStateNotExist:
+					balances[i] = big.NewInt(0)
+				default:
+					return errors.Wrapf(err, "failed to read the balance of %s", addrs[i])
+				}
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package accountutil
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_chainmanager"
+)
+
+func TestBalancesConcurrent(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the balance of identityset.Address(i) is i, and identityset.Address(0) doesn't exist
+	balances := map[hash.Hash160]int64{}
+	addrs := make([]string, 0, 20)
+	for i := 0; i < 20; i++ {
+		addr := identityset.Address(i)
+		addrs = append(addrs, addr.String())
+		if i > 0 {
+			balances[hash.BytesToHash160(addr.Bytes())] = int64(i)
+		}
+	}
+	failing := hash.BytesToHash160(identityset.Address(20).Bytes())
+	sr := mock_chainmanager.NewMockStateReader(ctrl)
+	sr.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+		cfg, err := protocol.CreateStateConfig(opts...)
+		require.NoError(err)
+		key := hash.BytesToHash160(cfg.Key)
+		if key == failing {
+			return 0, errors.New("db failure")
+		}
+		balance, ok := balances[key]
+		if !ok {
+			return 0, state.ErrStateNotExist
+		}
+		acct, err := state.NewAccount()
+		require.NoError(err)
+		require.NoError(acct.AddBalance(big.NewInt(balance)))
+		*s.(*state.Account) = *acct
+		return 0, nil
+	}).AnyTimes()
+
+	ctx := context.Background()
+	for _, workers := range []int{1, 3, 50} {
+		res, err := BalancesConcurrent(ctx, sr, addrs, workers)
+		require.NoError(err)
+		require.Len(res, len(addrs))
+		for i, balance := range res {
+			require.Equal(big.NewInt(int64(i)), balance)
+		}
+	}
+
+	_, err := BalancesConcurrent(ctx, sr, append(addrs, identityset.Address(20).String()), 4)
+	require.ErrorContains(err, "db failure")
+	_, err = BalancesConcurrent(ctx, sr, append(addrs, "invalid"), 4)
+	require.Error(err)
+	_, err = BalancesConcurrent(ctx, sr, addrs, 0)
+	require.Error(err)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = BalancesConcurrent(ctx, sr, addrs, 4)
+	require.Equal(context.Canceled, errors.Cause(err))
+}
@@ -1,9 +1,12 @@
 package protocol
 
 import (
+	"context"
+
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/state"
 )
 
@@ -84,6 +87,15 @@ type (
 		Dock
 	}
 
+	// WorkingSet defines the states of the block being worked on, which the actions of the block are run against
+	WorkingSet interface {
+		StateManager
+		// Process runs the actions and finalizes the working set
+		Process(context.Context, []action.SealedEnvelope) error
+		// Receipts returns the receipts of the actions run, after the working set is finalized
+		Receipts() ([]*action.Receipt, error)
+	}
+
 	// Dock defines an interface for protocol to read/write their private data in StateReader/Manager
 	// data are stored as interface{}, user needs to type-assert on their own upon Unload()
 	Dock interface {
@@ -152,6 +152,9 @@ func NewProtocol(
 			err error
 			ok  bool
 		)
+		if err = genesisConfig.Poll.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid poll config")
+		}
 		slasher, err = NewSlasher(
 			productivity,
 			getCandidates,
@@ -164,7 +167,7 @@ func NewProtocol(
 			genesisConfig.ProductivityThreshold,
 			genesisConfig.ProbationEpochPeriod,
 			genesisConfig.UnproductiveDelegateMaxCacheSize,
-			genesisConfig.ProbationIntensityRate)
+			genesisConfig.ProbationIntensity())
 		if err != nil {
 			return nil, err
 		}
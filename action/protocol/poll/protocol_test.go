@@ -27,30 +27,37 @@ func TestNewProtocol(t *testing.T) {
 	committee := mock_committee.NewMockCommittee(ctrl)
 	g := genesis.Default
 	g.ScoreThreshold = "1200000"
-	p, err := NewProtocol(
-		_rollDPoSScheme,
-		blockchain.DefaultConfig,
-		g,
-		nil,
-		func(context.Context, string, []byte, bool) ([]byte, error) { return nil, nil },
-		nil,
-		nil,
-		nil,
-		committee,
-		nil,
-		func(uint64) (time.Time, error) { return time.Now(), nil },
-		func(uint64, uint64) (map[string]uint64, error) {
-			return nil, nil
-		},
-		func(uint64) (hash.Hash256, error) {
-			return hash.ZeroHash256, nil
-		},
-		func(u uint64) (time.Time, error) {
-			return time.Time{}, nil
-		},
-	)
+	newProtocol := func(g genesis.Genesis) (Protocol, error) {
+		return NewProtocol(
+			_rollDPoSScheme,
+			blockchain.DefaultConfig,
+			g,
+			nil,
+			func(context.Context, string, []byte, bool) ([]byte, error) { return nil, nil },
+			nil,
+			nil,
+			nil,
+			committee,
+			nil,
+			func(uint64) (time.Time, error) { return time.Now(), nil },
+			func(uint64, uint64) (map[string]uint64, error) {
+				return nil, nil
+			},
+			func(uint64) (hash.Hash256, error) {
+				return hash.ZeroHash256, nil
+			},
+			func(u uint64) (time.Time, error) {
+				return time.Time{}, nil
+			},
+		)
+	}
+	p, err := newProtocol(g)
 	require.NoError(err)
 	require.NotNil(p)
+
+	g.ProbationIntensityRate = 101
+	_, err = newProtocol(g)
+	require.ErrorContains(err, "probation intensity rate 101 is larger than 100")
 }
 
 func TestFindProtocol(t *testing.T) {
@@ -82,6 +82,21 @@ func (r *Registry) All() []Protocol {
 	return r.all()
 }
 
+// IDs returns the IDs of all protocols in the order of registration
+func (r *Registry) IDs() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, len(r.ids))
+	for id, idx := range r.ids {
+		ids[idx] = id
+	}
+
+	return ids
+}
+
 func (r *Registry) all() []Protocol {
 	all := make([]Protocol, len(r.protocols))
 	copy(all, r.protocols)
@@ -54,3 +54,15 @@ func TestAll(t *testing.T) {
 	require.Equal(all[0], p)
 	require.Nil(all[1])
 }
+
+func TestIDs(t *testing.T) {
+	require := require.New(t)
+	var reg *Registry
+	require.Nil(reg.IDs())
+	reg = NewRegistry()
+	require.Empty(reg.IDs())
+	require.NoError(reg.Register("b", nil))
+	require.NoError(reg.Register("a", nil))
+	require.NoError(reg.ForceRegister("b", nil))
+	require.Equal([]string{"b", "a"}, reg.IDs())
+}
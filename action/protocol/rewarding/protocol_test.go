@@ -50,6 +50,10 @@ func TestValidateExtension(t *testing.T) {
 	g.FoundationBonusP2EndEpoch = last
 }
 
+func TestProtocolAddr(t *testing.T) {
+	require.Equal(t, genesis.Default.FundAddress(), ProtocolAddr())
+}
+
 func testProtocol(t *testing.T, test func(*testing.T, context.Context, protocol.StateManager, *Protocol), withExempt bool) {
 	ctrl := gomock.NewController(t)
 
@@ -244,6 +244,7 @@ func (d *Candidate) toStateCandidate() *state.Candidate {
 		Votes:         new(big.Int).Set(d.Votes),
 		RewardAddress: d.Reward.String(),
 		CanName:       []byte(d.Name),
+		SelfStake:     new(big.Int).Set(d.SelfStake),
 	}
 }
 
@@ -199,7 +199,7 @@ func (csm *candSM) updateBucket(index uint64, bucket *VoteBucket) error {
 
 	_, err := csm.PutState(
 		bucket,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(bucketKey(index)))
 	return err
 }
@@ -208,7 +208,7 @@ func (csm *candSM) putBucket(bucket *VoteBucket) (uint64, error) {
 	var tc totalBucketCount
 	if _, err := csm.State(
 		&tc,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(TotalBucketKey)); err != nil && errors.Cause(err) != state.ErrStateNotExist {
 		return 0, err
 	}
@@ -218,21 +218,21 @@ func (csm *candSM) putBucket(bucket *VoteBucket) (uint64, error) {
 	bucket.Index = index
 	if _, err := csm.PutState(
 		bucket,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(bucketKey(index))); err != nil {
 		return 0, err
 	}
 	tc.count++
 	_, err := csm.PutState(
 		&tc,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(TotalBucketKey))
 	return index, err
 }
 
 func (csm *candSM) delBucket(index uint64) error {
 	_, err := csm.DelState(
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(bucketKey(index)))
 	return err
 }
@@ -275,14 +275,14 @@ func (csm *candSM) putBucketIndex(addr address.Address, prefix byte, index uint6
 	)
 	if _, err := csm.State(
 		&bis,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(key)); err != nil && errors.Cause(err) != state.ErrStateNotExist {
 		return err
 	}
 	bis.addBucketIndex(index)
 	_, err := csm.PutState(
 		&bis,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(key))
 	return err
 }
@@ -298,7 +298,7 @@ func (csm *candSM) delBucketIndex(addr address.Address, prefix byte, index uint6
 	)
 	if _, err := csm.State(
 		&bis,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(key)); err != nil {
 		return err
 	}
@@ -307,12 +307,12 @@ func (csm *candSM) delBucketIndex(addr address.Address, prefix byte, index uint6
 	var err error
 	if len(bis) == 0 {
 		_, err = csm.DelState(
-			protocol.NamespaceOption(_stakingNameSpace),
+			protocol.NamespaceOption(StakingNameSpace),
 			protocol.KeyOption(key))
 	} else {
 		_, err = csm.PutState(
 			&bis,
-			protocol.NamespaceOption(_stakingNameSpace),
+			protocol.NamespaceOption(StakingNameSpace),
 			protocol.KeyOption(key))
 	}
 	return err
@@ -323,7 +323,7 @@ func (csm *candSM) delVoterBucketIndex(addr address.Address, index uint64) error
 }
 
 func (csm *candSM) putCandidate(d *Candidate) error {
-	_, err := csm.PutState(d, protocol.NamespaceOption(_candidateNameSpace), protocol.KeyOption(d.Owner.Bytes()))
+	_, err := csm.PutState(d, protocol.NamespaceOption(CandidateNameSpace), protocol.KeyOption(d.Owner.Bytes()))
 	return err
 }
 
@@ -332,7 +332,7 @@ func (csm *candSM) putCandBucketIndex(addr address.Address, index uint64) error
 }
 
 func (csm *candSM) delCandidate(name address.Address) error {
-	_, err := csm.DelState(protocol.NamespaceOption(_candidateNameSpace), protocol.KeyOption(name.Bytes()))
+	_, err := csm.DelState(protocol.NamespaceOption(CandidateNameSpace), protocol.KeyOption(name.Bytes()))
 	return err
 }
 
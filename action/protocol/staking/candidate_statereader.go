@@ -149,6 +149,34 @@ func ConstructBaseView(sr protocol.StateReader) (CandidateStateReader, error) {
 	}, nil
 }
 
+// StateCandidateByName returns the candidate of name in the base view, which is looked up by the name index of the
+// candidate center. The error wraps state.ErrStateNotExist if there is no such candidate.
+func StateCandidateByName(sr protocol.StateReader, name string) (*state.Candidate, error) {
+	csr, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, err
+	}
+	c := csr.GetCandidateByName(name)
+	if c == nil {
+		return nil, errors.Wrapf(state.ErrStateNotExist, "candidate %s doesn't exist", name)
+	}
+	return c.toStateCandidate(), nil
+}
+
+// StateCandidateByOwner returns the candidate owned by owner in the base view, which is looked up by the owner index
+// of the candidate center. The error wraps state.ErrStateNotExist if there is no such candidate.
+func StateCandidateByOwner(sr protocol.StateReader, owner address.Address) (*state.Candidate, error) {
+	csr, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, err
+	}
+	c := csr.GetCandidateByOwner(owner)
+	if c == nil {
+		return nil, errors.Wrapf(state.ErrStateNotExist, "candidate owned by %s doesn't exist", owner)
+	}
+	return c.toStateCandidate(), nil
+}
+
 // CreateBaseView creates the base view from state reader
 func CreateBaseView(sr protocol.StateReader, enableSMStorage bool) (*ViewData, uint64, error) {
 	if sr == nil {
@@ -181,7 +209,7 @@ func (c *candSR) getTotalBucketCount() (uint64, error) {
 	var tc totalBucketCount
 	_, err := c.State(
 		&tc,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(TotalBucketKey))
 	return tc.count, err
 }
@@ -193,14 +221,14 @@ func (c *candSR) getBucket(index uint64) (*VoteBucket, error) {
 	)
 	if _, err = c.State(
 		&vb,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(bucketKey(index))); err != nil {
 		return nil, err
 	}
 	var tc totalBucketCount
 	if _, err := c.State(
 		&tc,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(TotalBucketKey)); err != nil && errors.Cause(err) != state.ErrStateNotExist {
 		return nil, err
 	}
@@ -212,7 +240,7 @@ func (c *candSR) getBucket(index uint64) (*VoteBucket, error) {
 
 func (c *candSR) getAllBuckets() ([]*VoteBucket, uint64, error) {
 	height, iter, err := c.States(
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeysOption(func() ([][]byte, error) {
 			// TODO (zhi): fix potential racing issue
 			count, err := c.getTotalBucketCount()
@@ -280,7 +308,7 @@ func (c *candSR) getBucketIndices(addr address.Address, prefix byte) (*BucketInd
 	)
 	height, err := c.State(
 		&bis,
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(key))
 	if err != nil {
 		return nil, height, err
@@ -301,12 +329,12 @@ func (c *candSR) getCandidate(name address.Address) (*Candidate, uint64, error)
 		return nil, 0, ErrNilParameters
 	}
 	var d Candidate
-	height, err := c.State(&d, protocol.NamespaceOption(_candidateNameSpace), protocol.KeyOption(name.Bytes()))
+	height, err := c.State(&d, protocol.NamespaceOption(CandidateNameSpace), protocol.KeyOption(name.Bytes()))
 	return &d, height, err
 }
 
 func (c *candSR) getAllCandidates() (CandidateList, uint64, error) {
-	height, iter, err := c.States(protocol.NamespaceOption(_candidateNameSpace))
+	height, iter, err := c.States(protocol.NamespaceOption(CandidateNameSpace))
 	if err != nil {
 		return nil, height, err
 	}
@@ -331,7 +359,7 @@ func (c *candSR) NewBucketPool(enableSMStorage bool) (*BucketPool, error) {
 	}
 
 	if bp.enableSMStorage {
-		switch _, err := c.State(bp.total, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey)); errors.Cause(err) {
+		switch _, err := c.State(bp.total, protocol.NamespaceOption(StakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey)); errors.Cause(err) {
 		case nil:
 			return &bp, nil
 		case state.ErrStateNotExist:
@@ -11,6 +11,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/testutil/testdb"
 	"github.com/stretchr/testify/require"
 )
@@ -38,3 +40,33 @@ func Test_CandidateStateReader(t *testing.T) {
 	require.Equal(csr.TotalStakedAmount(), big.NewInt(0))
 	require.Equal(csr.ActiveBucketsCount(), uint64(0))
 }
+
+func TestStateCandidateByName(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm := testdb.NewMockStateManager(ctrl)
+
+	_, err := StateCandidateByName(sm, testCandidates[0].d.Name)
+	require.Equal(protocol.ErrNoName, err)
+	view, _, err := CreateBaseView(sm, false)
+	require.NoError(err)
+	for i := 0; i < 3; i++ {
+		require.NoError(view.candCenter.Upsert(testCandidates[i].d))
+	}
+	require.NoError(view.candCenter.Commit())
+	require.NoError(sm.WriteView(_protocolID, view))
+
+	for i := 0; i < 3; i++ {
+		d := testCandidates[i].d
+		c, err := StateCandidateByName(sm, d.Name)
+		require.NoError(err)
+		require.Equal(d.toStateCandidate(), c)
+		c, err = StateCandidateByOwner(sm, d.Owner)
+		require.NoError(err)
+		require.Equal(d.toStateCandidate(), c)
+	}
+	_, err = StateCandidateByName(sm, "notexist")
+	require.ErrorIs(err, state.ErrStateNotExist)
+	_, err = StateCandidateByOwner(sm, identityset.Address(30))
+	require.ErrorIs(err, state.ErrStateNotExist)
+}
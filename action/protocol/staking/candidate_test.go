@@ -102,6 +102,7 @@ func TestClone(t *testing.T) {
 	r.Equal(d.Reward.String(), c.RewardAddress)
 	r.Equal(d.Votes, c.Votes)
 	r.Equal(d.Name, string(c.CanName))
+	r.Equal(d.SelfStake, c.SelfStake)
 }
 
 var (
@@ -219,21 +219,5 @@ func bucketKey(index uint64) []byte {
 
 // CalculateVoteWeight calculates the vote weight
 func CalculateVoteWeight(c genesis.VoteWeightCalConsts, v *VoteBucket, selfStake bool) *big.Int {
-	remainingTime := v.StakedDuration.Seconds()
-	weight := float64(1)
-	var m float64
-	if v.AutoStake {
-		m = c.AutoStake
-	}
-	if remainingTime > 0 {
-		weight += math.Log(math.Ceil(remainingTime/86400)*(1+m)) / math.Log(c.DurationLg) / 100
-	}
-	if selfStake && v.AutoStake && v.StakedDuration >= time.Duration(91)*24*time.Hour {
-		// self-stake extra bonus requires enable auto-stake for at least 3 months
-		weight *= c.SelfStake
-	}
-
-	amount := new(big.Float).SetInt(v.StakedAmount)
-	weightedAmount, _ := amount.Mul(amount, big.NewFloat(weight)).Int(nil)
-	return weightedAmount
+	return c.VoteWeight(v.StakedAmount, v.StakedDuration, v.AutoStake, selfStake)
 }
@@ -37,7 +37,7 @@ func TestGetPutStaking(t *testing.T) {
 	csr := newCandidateStateReader(sm)
 	sm.PutState(
 		&totalBucketCount{count: 0},
-		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.NamespaceOption(StakingNameSpace),
 		protocol.KeyOption(TotalBucketKey),
 	)
 
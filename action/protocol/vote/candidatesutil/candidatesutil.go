@@ -7,7 +7,6 @@ package candidatesutil
 
 import (
 	"math/big"
-	"sort"
 
 	"go.uber.org/zap"
 
@@ -83,6 +82,16 @@ func CandidatesFromDB(sr protocol.StateReader, height uint64, loadCandidatesLega
 	)
 }
 
+// CandidatesByHeightPaged returns the page of candidates in candidate pool of a given height, which has at most limit
+// candidates starting from offset in the canonical order, and the total number of candidates
+func CandidatesByHeightPaged(sr protocol.StateReader, height uint64, offset, limit uint32) ([]*state.Candidate, uint64, error) {
+	var candidates state.CandidateList
+	if _, err := sr.State(&candidates, protocol.LegacyKeyOption(ConstructLegacyKey(height))); err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to get candidates at height %d", height)
+	}
+	return candidates.Page(offset, limit), uint64(len(candidates)), nil
+}
+
 // ProbationListFromDB returns array of probation list at current epoch
 func ProbationListFromDB(sr protocol.StateReader, epochStartPoint bool) (*vote.ProbationList, uint64, error) {
 	probationList := &vote.ProbationList{}
@@ -200,7 +209,6 @@ func storeCandidates(candidateMap map[hash.Hash160]*state.Candidate, sm protocol
 	if err != nil {
 		return errors.Wrap(err, "failed to convert candidate map to candidate list")
 	}
-	sort.Sort(candidateList)
 	candidatesKey := ConstructLegacyKey(blkHeight)
 	_, err = sm.PutState(&candidateList, protocol.LegacyKeyOption(candidatesKey))
 	return err
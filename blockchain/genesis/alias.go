@@ -0,0 +1,109 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+var (
+	// _deprecatedKeys maps the deprecated yaml keys of a genesis section to the current keys, so that an archived
+	// genesis config using the old keys could still be loaded
+	_deprecatedKeys = map[reflect.Type]map[string]string{
+		reflect.TypeOf(Poll{}): {
+			// the key of UnproductiveDelegateMaxCacheSize was all lower case due to a malformed yaml tag
+			"unproductivedelegatemaxcachesize": "unproductiveDelegateMaxCacheSize",
+		},
+		reflect.TypeOf(Rewarding{}): {
+			"bootstrapBonus": "foundationBonus",
+		},
+	}
+	// _warnedDeprecatedKeys records the deprecated keys warned about, so each is warned once
+	_warnedDeprecatedKeys sync.Map
+)
+
+// renameDeprecatedKeys returns the yaml source with the deprecated keys in _deprecatedKeys renamed to the current keys,
+// and warns about each deprecated key once. A deprecated key is dropped if the current key is given as well, so the
+// current key takes effect. The source is returned as is if there is no deprecated key, or it cannot be parsed, which
+// is left to the loading to report.
+func renameDeprecatedKeys(src []byte) []byte {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(src, &doc); err != nil || len(doc.Content) == 0 {
+		return src
+	}
+	if !renameDeprecatedNodes(reflect.TypeOf(Genesis{}), doc.Content[0]) {
+		return src
+	}
+	renamed, err := yaml.Marshal(&doc)
+	if err != nil {
+		return src
+	}
+	return renamed
+}
+
+// renameDeprecatedNodes walks the yaml node along the type t, and renames or drops the deprecated keys. It returns
+// whether any key is renamed or dropped.
+func renameDeprecatedNodes(t reflect.Type, n *yaml.Node) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	changed := false
+	switch t.Kind() {
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Duration(0)) || n.Kind != yaml.MappingNode {
+			return false
+		}
+		present := make(map[string]bool, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			present[n.Content[i].Value] = true
+		}
+		content := make([]*yaml.Node, 0, len(n.Content))
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if current, ok := _deprecatedKeys[t][key.Value]; ok {
+				if _, warned := _warnedDeprecatedKeys.LoadOrStore(t.Name()+"."+key.Value, true); !warned {
+					log.L().Warn("Deprecated genesis key.", zap.String("key", key.Value), zap.String("currentKey", current))
+				}
+				changed = true
+				if present[current] {
+					continue
+				}
+				key.Value = current
+			}
+			if f, ok := fieldByYAMLKey(t, key.Value); ok && renameDeprecatedNodes(f.Type, value) {
+				changed = true
+			}
+			content = append(content, key, value)
+		}
+		n.Content = content
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			return false
+		}
+		for _, elem := range n.Content {
+			if renameDeprecatedNodes(t.Elem(), elem) {
+				changed = true
+			}
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			return false
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if renameDeprecatedNodes(t.Elem(), n.Content[i+1]) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
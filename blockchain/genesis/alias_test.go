@@ -0,0 +1,76 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameDeprecatedKeys(t *testing.T) {
+	require := require.New(t)
+	for _, v := range []struct {
+		src, expected string
+	}{
+		{
+			"rewarding:\n    bootstrapBonus: \"100\" # bonus\n    blockReward: 16000000000000000000\n",
+			"rewarding:\n    foundationBonus: \"100\" # bonus\n    blockReward: 16000000000000000000\n",
+		},
+		{
+			"rewarding: {bootstrapBonus: 100, epochReward: '2'}\n",
+			"rewarding: {foundationBonus: 100, epochReward: '2'}\n",
+		},
+		// the current key takes effect if both are given
+		{
+			"rewarding:\n    bootstrapBonus: \"100\"\n    foundationBonus: \"200\"\n",
+			"rewarding:\n    foundationBonus: \"200\"\n",
+		},
+		// no deprecated key
+		{"rewarding:\n  blockReward: 16 # bonus\n", "rewarding:\n  blockReward: 16 # bonus\n"},
+		// a deprecated key is only recognized in its section
+		{"blockchain:\n  bootstrapBonus: \"100\"\n", "blockchain:\n  bootstrapBonus: \"100\"\n"},
+		{"rewarding: [\n", "rewarding: [\n"},
+		{"", ""},
+	} {
+		require.Equal(v.expected, string(renameDeprecatedKeys([]byte(v.src))))
+	}
+}
+
+func TestNew_DeprecatedKeys(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "genesis.yaml")
+	require.NoError(os.WriteFile(path, []byte("rewarding:\n  bootstrapBonus: 100000000000000000000000\n"), 0600))
+	for _, load := range []func(string) (Genesis, error){New, NewStrict} {
+		g, err := load(path)
+		require.NoError(err)
+		require.Equal("100000000000000000000000", g.FoundationBonusStr)
+	}
+
+	require.NoError(os.WriteFile(path, []byte("rewarding:\n  bootstrapBonus: \"1\"\n  foundationBonus: \"2\"\n"), 0600))
+	for _, load := range []func(string) (Genesis, error){New, NewStrict} {
+		g, err := load(path)
+		require.NoError(err)
+		require.Equal("2", g.FoundationBonusStr)
+	}
+}
+
+func TestNew_UnproductiveDelegateMaxCacheSize(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "genesis.yaml")
+	// the key in camel case is decoded since the yaml tag is fixed, while the lower case key, which was the only one
+	// decoded before, is still recognized as a deprecated key
+	for _, key := range []string{"unproductiveDelegateMaxCacheSize", "unproductivedelegatemaxcachesize"} {
+		require.NoError(os.WriteFile(path, []byte("poll:\n  "+key+": 30\n"), 0600))
+		for _, load := range []func(string) (Genesis, error){New, NewStrict} {
+			g, err := load(path)
+			require.NoError(err)
+			require.EqualValues(30, g.UnproductiveDelegateMaxCacheSize)
+		}
+	}
+}
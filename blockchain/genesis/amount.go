@@ -0,0 +1,109 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// quoteNumericStrings returns the yaml source with the numbers given to string fields quoted, e.g.,
+// blockReward: 16000000000000000000 becomes blockReward: "16000000000000000000". Otherwise the yaml decoder takes
+// such an amount as a number, and a large one lands in the string field as a float like 1.6e+19. The source is
+// returned as is if it cannot be parsed, which is left to the loading to report.
+func quoteNumericStrings(src []byte) []byte {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(src, &doc); err != nil || len(doc.Content) == 0 {
+		return src
+	}
+	var scalars []*yaml.Node
+	collectNumericStrings(reflect.TypeOf(Genesis{}), doc.Content[0], &scalars)
+	if len(scalars) == 0 {
+		return src
+	}
+	// quote from the end, so the positions of the scalars ahead don't shift
+	sort.Slice(scalars, func(i, j int) bool {
+		if scalars[i].Line != scalars[j].Line {
+			return scalars[i].Line > scalars[j].Line
+		}
+		return scalars[i].Column > scalars[j].Column
+	})
+	lines := bytes.Split(src, []byte("\n"))
+	for _, n := range scalars {
+		if n.Line < 1 || n.Line > len(lines) {
+			continue
+		}
+		line := lines[n.Line-1]
+		start := byteOffset(line, n.Column-1)
+		end := start + len(n.Value)
+		if start < 0 || end > len(line) || string(line[start:end]) != n.Value {
+			// e.g., an anchored scalar
+			continue
+		}
+		quoted := make([]byte, 0, len(line)+2)
+		quoted = append(quoted, line[:start]...)
+		quoted = append(quoted, '"')
+		quoted = append(quoted, n.Value...)
+		quoted = append(quoted, '"')
+		lines[n.Line-1] = append(quoted, line[end:]...)
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// collectNumericStrings walks the yaml node along the type t, and collects the plain numeric scalars given to the
+// string fields
+func collectNumericStrings(t reflect.Type, n *yaml.Node, scalars *[]*yaml.Node) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Duration(0)) || n.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if f, ok := fieldByYAMLKey(t, n.Content[i].Value); ok {
+				collectNumericStrings(f.Type, n.Content[i+1], scalars)
+			}
+		}
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, elem := range n.Content {
+			collectNumericStrings(t.Elem(), elem, scalars)
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			collectNumericStrings(t.Elem(), n.Content[i+1], scalars)
+		}
+	case reflect.String:
+		if n.Kind == yaml.ScalarNode && n.Style == 0 && (n.ShortTag() == "!!int" || n.ShortTag() == "!!float") {
+			*scalars = append(*scalars, n)
+		}
+	}
+}
+
+// byteOffset returns the offset in bytes of the character at column in the line, or -1 if the line is shorter
+func byteOffset(line []byte, column int) int {
+	offset := 0
+	for i := 0; i < column; i++ {
+		if offset >= len(line) {
+			return -1
+		}
+		_, size := utf8.DecodeRune(line[offset:])
+		offset += size
+	}
+	return offset
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestQuoteNumericStrings(t *testing.T) {
+	require := require.New(t)
+	for _, v := range []struct {
+		src, expected string
+	}{
+		{
+			"rewarding:\n  blockReward: 16000000000000000000 # 16 IOTX\n  numDelegatesForFoundationBonus: 36\n",
+			"rewarding:\n  blockReward: \"16000000000000000000\" # 16 IOTX\n  numDelegatesForFoundationBonus: 36\n",
+		},
+		{
+			"rewarding: {blockReward: 1, epochReward: '2', foundationBonus: 100000000000000000000000}\n",
+			"rewarding: {blockReward: \"1\", epochReward: '2', foundationBonus: \"100000000000000000000000\"}\n",
+		},
+		{
+			"account:\n  initBalances:\n    io1a: 100000000000000000000000000\n    io1b: \"5\"\n",
+			"account:\n  initBalances:\n    io1a: \"100000000000000000000000000\"\n    io1b: \"5\"\n",
+		},
+		{
+			"poll:\n  delegates:\n    - operatorAddr: io1a\n      votes: 1e+23\n",
+			"poll:\n  delegates:\n    - operatorAddr: io1a\n      votes: \"1e+23\"\n",
+		},
+		{
+			"blockchain:\n  chainName: 名字\n  numDelegates: 24\n",
+			"blockchain:\n  chainName: 名字\n  numDelegates: 24\n",
+		},
+		{
+			"account:\n  initBalances: {名字: 12, io1b: 3}\n",
+			"account:\n  initBalances: {名字: \"12\", io1b: \"3\"}\n",
+		},
+		// unknown keys and invalid yaml are left as is
+		{"rewarding:\n  blockRewardz: 12\n", "rewarding:\n  blockRewardz: 12\n"},
+		{"rewarding: [\n", "rewarding: [\n"},
+		{"", ""},
+	} {
+		require.Equal(v.expected, string(quoteNumericStrings([]byte(v.src))))
+	}
+}
+
+func TestNew_NumericAmounts(t *testing.T) {
+	require := require.New(t)
+	addr0, addr1 := identityset.Address(0).String(), identityset.Address(1).String()
+	src := `rewarding:
+  blockReward: 16000000000000000000
+  epochReward: "12500000000000000000000"
+  foundationBonus: 100000000000000000000000
+account:
+  initBalances:
+    ` + addr0 + `: 1000000000000000000000000000
+    ` + addr1 + `: "1000000000000000000000000000"
+poll:
+  delegates:
+    - operatorAddr: ` + addr0 + `
+      rewardAddr: ` + addr0 + `
+      votes: 30000000000000000000000000
+`
+	path := filepath.Join(t.TempDir(), "genesis.yaml")
+	require.NoError(os.WriteFile(path, []byte(src), 0600))
+	g, err := New(path)
+	require.NoError(err)
+	require.NoError(g.Validate())
+	require.Equal("16000000000000000000", g.BlockRewardStr)
+	require.Equal("12500000000000000000000", g.EpochRewardStr)
+	require.Equal("100000000000000000000000", g.FoundationBonusStr)
+	require.Equal(map[string]string{
+		addr0: "1000000000000000000000000000",
+		addr1: "1000000000000000000000000000",
+	}, g.InitBalanceMap)
+	require.Equal("30000000000000000000000000", g.Delegates[0].VotesStr)
+
+	// round trip through EditYAML
+	require.NoError(EditYAML(path, func(g *Genesis) error {
+		g.BlockRewardStr = "8000000000000000000"
+		return nil
+	}))
+	edited, err := New(path)
+	require.NoError(err)
+	require.Equal("8000000000000000000", edited.BlockRewardStr)
+	edited.BlockRewardStr = g.BlockRewardStr
+	require.Equal(g, edited)
+}
+
+func TestNew_InitBalancesIotx(t *testing.T) {
+	require := require.New(t)
+	addr0, addr1, addr2 := identityset.Address(0).String(), identityset.Address(1).String(), identityset.Address(2).String()
+	path := filepath.Join(t.TempDir(), "genesis.yaml")
+	require.NoError(os.WriteFile(path, []byte(`account:
+  initBalances:
+    `+addr0+`: "100"
+  initBalancesIotx:
+    `+addr1+`: 1.5
+    `+addr2+`: "2000000000"
+`), 0600))
+	g, err := New(path)
+	require.NoError(err)
+	require.NoError(g.Validate())
+	require.Empty(g.InitBalancesIotx)
+	require.Equal("100", g.InitBalanceMap[addr0])
+	require.Equal("1500000000000000000", g.InitBalanceMap[addr1])
+	require.Equal("2000000000000000000000000000", g.InitBalanceMap[addr2])
+
+	// an address in both maps
+	require.NoError(os.WriteFile(path, []byte(`account:
+  initBalances:
+    `+addr0+`: "100"
+  initBalancesIotx:
+    `+addr0+`: 1
+`), 0600))
+	_, err = New(path)
+	require.ErrorContains(err, "init balance of "+addr0+" is given in both Rau and IOTX")
+
+	for _, v := range []struct {
+		iotx, rau, errMsg string
+	}{
+		{"0", "0", ""},
+		{"1", "1000000000000000000", ""},
+		{"0.000000000000000001", "1", ""},
+		{"12.34", "12340000000000000000", ""},
+		{"0.0000000000000000001", "", "has more than 18 decimals"},
+		{"-1.5", "", "is negative"},
+		{".5", "", "invalid"},
+		{"1.-5", "", "invalid"},
+		{"1e18", "", "invalid"},
+		{"", "", "invalid"},
+	} {
+		rau, err := parseIotx("balance", v.iotx)
+		if v.errMsg != "" {
+			require.ErrorContains(err, v.errMsg, v.iotx)
+			continue
+		}
+		require.NoError(err)
+		require.Equal(v.rau, rau.String())
+	}
+
+	// a genesis not loaded by New is validated as well
+	g = TestDefault()
+	g.InitBalancesIotx = map[string]string{"io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6": "1.2.3"}
+	require.ErrorContains(g.Validate(), "invalid init balance of io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6")
+	g.InitBalancesIotx = map[string]string{addr1: "1"}
+	require.ErrorContains(g.Validate(), "init balance of "+addr1+" is given in both Rau and IOTX")
+}
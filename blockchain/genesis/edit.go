@@ -0,0 +1,124 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// EditYAML loads the genesis config from the yaml file at path as New does, applies mutate to it, and writes the fields
+// changed by mutate back to the file. The other contents of the file, including the comments and the order of the
+// keys, are kept. A changed field missing in the file is appended to its section. The file is left untouched if
+// mutate fails or the mutated genesis is invalid.
+func EditYAML(path string, mutate func(*Genesis) error) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read genesis yaml")
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(src, &doc); err != nil {
+		return errors.Wrap(err, "failed to parse genesis yaml")
+	}
+	if doc.Kind == 0 {
+		// empty file
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return errors.New("genesis yaml is not a mapping")
+	}
+
+	g, err := New(path)
+	if err != nil {
+		return err
+	}
+	orig := g.Clone()
+	if err := mutate(&g); err != nil {
+		return err
+	}
+	if err := g.Validate(); err != nil {
+		return errors.Wrap(err, "invalid genesis after editing")
+	}
+	if err := editNodes(doc.Content[0], reflect.ValueOf(orig), reflect.ValueOf(g)); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return errors.Wrap(err, "failed to serialize genesis yaml")
+	}
+	if err := enc.Close(); err != nil {
+		return errors.Wrap(err, "failed to serialize genesis yaml")
+	}
+	return os.WriteFile(path, buf.Bytes(), info.Mode().Perm())
+}
+
+// editNodes updates the mapping node with the fields of the struct which differ between orig and curr
+func editNodes(node *yaml.Node, orig, curr reflect.Value) error {
+	t := curr.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		o, c := orig.Field(i), curr.Field(i)
+		if reflect.DeepEqual(o.Interface(), c.Interface()) {
+			continue
+		}
+		key := yamlKey(f)
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Duration(0)) {
+			child := mappingValue(node, key)
+			if child == nil {
+				child = &yaml.Node{Kind: yaml.MappingNode}
+				appendKey(node, key, child)
+			}
+			if child.Kind != yaml.MappingNode {
+				return errors.Errorf("value of %s is not a mapping", key)
+			}
+			if err := editNodes(child, o, c); err != nil {
+				return err
+			}
+			continue
+		}
+		value := &yaml.Node{}
+		if err := value.Encode(c.Interface()); err != nil {
+			return errors.Wrapf(err, "failed to serialize %s", key)
+		}
+		if old := mappingValue(node, key); old != nil {
+			// keep the comments of the old value
+			value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			*old = *value
+			continue
+		}
+		appendKey(node, key, value)
+	}
+	return nil
+}
+
+// mappingValue returns the value node of the key in the mapping node, or nil if the key doesn't exist
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func appendKey(node *yaml.Node, key string, value *yaml.Node) {
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
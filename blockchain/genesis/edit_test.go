@@ -0,0 +1,69 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditYAML(t *testing.T) {
+	require := require.New(t)
+
+	const src = `# genesis of the devnet
+blockchain:
+  # bumped for the upgrade
+  sumatraHeight: 30000000 # sumatra
+  redseaHeight: 29000000
+staking:
+  withdrawWaitingPeriod: 72h
+`
+	path := filepath.Join(t.TempDir(), "genesis.yaml")
+	require.NoError(os.WriteFile(path, []byte(src), 0600))
+
+	require.NoError(EditYAML(path, func(g *Genesis) error {
+		g.SumatraBlockHeight = 31000000
+		g.WithdrawWaitingPeriod = 24 * time.Hour
+		g.ProductivityThreshold = 90
+		return nil
+	}))
+	data, err := os.ReadFile(path)
+	require.NoError(err)
+	require.Equal(`# genesis of the devnet
+blockchain:
+  # bumped for the upgrade
+  sumatraHeight: 31000000 # sumatra
+  redseaHeight: 29000000
+staking:
+  withdrawWaitingPeriod: 24h0m0s
+rewarding:
+  productivityThreshold: 90
+`, string(data))
+	g, err := New(path)
+	require.NoError(err)
+	require.EqualValues(31000000, g.SumatraBlockHeight)
+	require.EqualValues(29000000, g.RedseaBlockHeight)
+	require.Equal(24*time.Hour, g.WithdrawWaitingPeriod)
+	require.EqualValues(90, g.ProductivityThreshold)
+
+	// the file is untouched if the mutation fails or breaks the genesis
+	require.Error(EditYAML(path, func(g *Genesis) error {
+		g.SumatraBlockHeight = 1
+		return errors.New("failed")
+	}))
+	require.Error(EditYAML(path, func(g *Genesis) error {
+		g.SumatraBlockHeight = 1
+		return nil
+	}))
+	unchanged, err := os.ReadFile(path)
+	require.NoError(err)
+	require.Equal(data, unchanged)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.uber.org/config"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding the genesis config.
+//
+// The name of the environment variable of a genesis field is EnvPrefix followed by the upper-cased yaml keys on the
+// path to the field, joined by "_". The top level sections (blockchain, account, poll, rewarding and staking) are
+// omitted from the path, the same way as their fields are promoted in Genesis. For example:
+//
+//	blockchain.sumatraHeight                    => IOTEX_GENESIS_SUMATRAHEIGHT
+//	staking.voteWeightCalConsts.durationLg      => IOTEX_GENESIS_VOTEWEIGHTCALCONSTS_DURATIONLG
+//
+// Only scalar fields could be overridden, lists and maps such as the initial balances are left to the yaml file.
+const EnvPrefix = "IOTEX_GENESIS_"
+
+// envOverrides returns a yaml source referring to the environment variables which are set for the genesis fields.
+// The references are resolved by config.Expand.
+func envOverrides(lookup config.LookupFunc) []byte {
+	var buf bytes.Buffer
+	t := reflect.TypeOf(Genesis{})
+	for i := 0; i < t.NumField(); i++ {
+		section := t.Field(i)
+		var fields bytes.Buffer
+		writeEnvOverrides(&fields, lookup, section.Type, "", 1)
+		if fields.Len() > 0 {
+			buf.WriteString(yamlKey(section) + ":\n")
+			buf.Write(fields.Bytes())
+		}
+	}
+	return buf.Bytes()
+}
+
+func writeEnvOverrides(buf *bytes.Buffer, lookup config.LookupFunc, t reflect.Type, path string, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		key := yamlKey(f)
+		name := path + strings.ToUpper(key)
+		switch {
+		case f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Duration(0)):
+			var fields bytes.Buffer
+			writeEnvOverrides(&fields, lookup, f.Type, name+"_", depth+1)
+			if fields.Len() > 0 {
+				buf.WriteString(indent + key + ":\n")
+				buf.Write(fields.Bytes())
+			}
+		case f.Type.Kind() == reflect.Slice, f.Type.Kind() == reflect.Map:
+		default:
+			if _, ok := lookup(EnvPrefix + name); ok {
+				buf.WriteString(indent + key + ": ${" + EnvPrefix + name + "}\n")
+			}
+		}
+	}
+}
+
+// yamlKey returns the yaml key of the field, which is the field name in lower case if it has no yaml tag
+func yamlKey(f reflect.StructField) string {
+	if key := strings.Split(f.Tag.Get("yaml"), ",")[0]; key != "" {
+		return key
+	}
+	return strings.ToLower(f.Name)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithEnvOverrides(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "genesis.yaml")
+	require.NoError(os.WriteFile(path, []byte(`
+blockchain:
+  chainName: ${TEST_GENESIS_NAME}
+  sumatraHeight: 100
+  quebecHeight: 90
+staking:
+  voteWeightCalConsts:
+    durationLg: 1.5
+`), 0644))
+
+	g, err := New(path)
+	require.NoError(err)
+	require.EqualValues(100, g.SumatraBlockHeight)
+	require.EqualValues(90, g.QuebecBlockHeight)
+	require.Equal(1.5, g.VoteWeightCalConsts.DurationLg)
+
+	t.Setenv("TEST_GENESIS_NAME", "devnet")
+	t.Setenv("IOTEX_GENESIS_SUMATRAHEIGHT", "123")
+	t.Setenv("IOTEX_GENESIS_VOTEWEIGHTCALCONSTS_DURATIONLG", "2.5")
+	t.Setenv("IOTEX_GENESIS_BLOCKINTERVAL", "5s")
+	t.Setenv("IOTEX_GENESIS_MINSTAKEAMOUNT", "1000")
+	g, err = New(path)
+	require.NoError(err)
+	require.EqualValues(123, g.SumatraBlockHeight)
+	require.EqualValues(90, g.QuebecBlockHeight)
+	// only the environment overrides are expanded, while the yaml file is taken literally
+	require.Equal("${TEST_GENESIS_NAME}", g.ChainName)
+	require.Equal(2.5, g.VoteWeightCalConsts.DurationLg)
+	require.Equal(5*time.Second, g.BlockInterval)
+	require.Equal("1000", g.MinStakeAmount)
+
+	// env overrides apply without a yaml file as well
+	g, err = New("")
+	require.NoError(err)
+	require.EqualValues(123, g.SumatraBlockHeight)
+
+	t.Setenv("IOTEX_GENESIS_SUMATRAHEIGHT", "abc")
+	_, err = New(path)
+	require.Error(err)
+}
+
+func TestEnvOverrideNames(t *testing.T) {
+	require := require.New(t)
+
+	// the names of the environment variables must not collide across the sections
+	names := make(map[string]int)
+	envOverrides(func(name string) (string, bool) {
+		names[name]++
+		return "", false
+	})
+	require.Contains(names, "IOTEX_GENESIS_SUMATRAHEIGHT")
+	require.Contains(names, "IOTEX_GENESIS_REGISTRATIONCONSTS_MINSELFSTAKE")
+	for name, n := range names {
+		require.Equal(1, n, name)
+	}
+}
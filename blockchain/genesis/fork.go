@@ -0,0 +1,208 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// Fork is a hard fork of the blockchain, the forks are defined in the order of activation
+	Fork uint8
+
+	// ForkActivation is the name of a fork and its start height
+	ForkActivation struct {
+		Name   string
+		Height uint64
+	}
+)
+
+// hard forks of the blockchain
+const (
+	Pacific Fork = iota
+	Aleutian
+	Bering
+	Cook
+	Dardanelles
+	Daytona
+	Easter
+	FbkMigration
+	Fairbank
+	Greenland
+	Hawaii
+	Iceland
+	Jutland
+	Kamchatka
+	LordHowe
+	Midway
+	Newfoundland
+	Okhotsk
+	Palau
+	Quebec
+	Redsea
+	Sumatra
+	ToBeEnabled
+)
+
+var _forkNames = []string{
+	"pacific",
+	"aleutian",
+	"bering",
+	"cook",
+	"dardanelles",
+	"daytona",
+	"easter",
+	"fbkMigration",
+	"fairbank",
+	"greenland",
+	"hawaii",
+	"iceland",
+	"jutland",
+	"kamchatka",
+	"lordHowe",
+	"midway",
+	"newfoundland",
+	"okhotsk",
+	"palau",
+	"quebec",
+	"redsea",
+	"sumatra",
+	"toBeEnabled",
+}
+
+// String returns the name of the fork
+func (f Fork) String() string {
+	if int(f) >= len(_forkNames) {
+		return "unknown"
+	}
+	return _forkNames[f]
+}
+
+// Forks returns all the forks in the order of activation
+func Forks() []Fork {
+	forks := make([]Fork, 0, len(_forkNames))
+	for f := Pacific; f <= ToBeEnabled; f++ {
+		forks = append(forks, f)
+	}
+	return forks
+}
+
+// forkHeight returns the pointer to the height field of the fork, or nil for an unknown fork
+func (g *Blockchain) forkHeight(f Fork) *uint64 {
+	switch f {
+	case Pacific:
+		return &g.PacificBlockHeight
+	case Aleutian:
+		return &g.AleutianBlockHeight
+	case Bering:
+		return &g.BeringBlockHeight
+	case Cook:
+		return &g.CookBlockHeight
+	case Dardanelles:
+		return &g.DardanellesBlockHeight
+	case Daytona:
+		return &g.DaytonaBlockHeight
+	case Easter:
+		return &g.EasterBlockHeight
+	case FbkMigration:
+		return &g.FbkMigrationBlockHeight
+	case Fairbank:
+		return &g.FairbankBlockHeight
+	case Greenland:
+		return &g.GreenlandBlockHeight
+	case Hawaii:
+		return &g.HawaiiBlockHeight
+	case Iceland:
+		return &g.IcelandBlockHeight
+	case Jutland:
+		return &g.JutlandBlockHeight
+	case Kamchatka:
+		return &g.KamchatkaBlockHeight
+	case LordHowe:
+		return &g.LordHoweBlockHeight
+	case Midway:
+		return &g.MidwayBlockHeight
+	case Newfoundland:
+		return &g.NewfoundlandBlockHeight
+	case Okhotsk:
+		return &g.OkhotskBlockHeight
+	case Palau:
+		return &g.PalauBlockHeight
+	case Quebec:
+		return &g.QuebecBlockHeight
+	case Redsea:
+		return &g.RedseaBlockHeight
+	case Sumatra:
+		return &g.SumatraBlockHeight
+	case ToBeEnabled:
+		return &g.ToBeEnabledBlockHeight
+	default:
+		return nil
+	}
+}
+
+// ForkHeight returns the start height of the fork
+func (g *Blockchain) ForkHeight(f Fork) (uint64, error) {
+	h := g.forkHeight(f)
+	if h == nil {
+		return 0, errors.Errorf("unknown fork %d", f)
+	}
+	return *h, nil
+}
+
+// ForkSchedule returns the forks and their start heights sorted by height. A fork at height math.MaxUint64, e.g.,
+// ToBeEnabled by default, is not scheduled yet and is excluded.
+func (g *Blockchain) ForkSchedule() []ForkActivation {
+	schedule := make([]ForkActivation, 0, len(_forkNames))
+	for _, f := range Forks() {
+		h := *g.forkHeight(f)
+		if h == math.MaxUint64 {
+			continue
+		}
+		schedule = append(schedule, ForkActivation{Name: f.String(), Height: h})
+	}
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].Height < schedule[j].Height
+	})
+	return schedule
+}
+
+// validateForkHeights checks that the fork heights are non-decreasing in the order of activation
+func (g *Blockchain) validateForkHeights() error {
+	forks := Forks()
+	for i := 1; i < len(forks); i++ {
+		prev, curr := *g.forkHeight(forks[i-1]), *g.forkHeight(forks[i])
+		if curr < prev {
+			return errors.Errorf("%s height %d is lower than %s height %d", forks[i], curr, forks[i-1], prev)
+		}
+	}
+	return nil
+}
+
+// WithForkHeight returns a copy of the genesis with the start height of the fork overridden
+func (g Genesis) WithForkHeight(fork Fork, height uint64) (Genesis, error) {
+	return g.WithForkHeights(map[Fork]uint64{fork: height})
+}
+
+// WithForkHeights returns a copy of the genesis with the start heights of the forks overridden. An error is returned
+// if the overrides break the validity of the genesis, e.g., the order of the fork heights.
+func (g Genesis) WithForkHeights(heights map[Fork]uint64) (Genesis, error) {
+	clone := g.Clone()
+	for fork, height := range heights {
+		h := clone.forkHeight(fork)
+		if h == nil {
+			return Genesis{}, errors.Errorf("unknown fork %d", fork)
+		}
+		*h = height
+	}
+	if err := clone.Validate(); err != nil {
+		return Genesis{}, err
+	}
+	return clone, nil
+}
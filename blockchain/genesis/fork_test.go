@@ -0,0 +1,85 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFork(t *testing.T) {
+	require := require.New(t)
+	forks := Forks()
+	require.Len(forks, int(ToBeEnabled)+1)
+	require.Equal("pacific", Pacific.String())
+	require.Equal("toBeEnabled", ToBeEnabled.String())
+	require.Equal("unknown", Fork(100).String())
+
+	g := TestDefault()
+	for _, f := range forks {
+		_, err := g.ForkHeight(f)
+		require.NoError(err)
+	}
+	h, err := g.ForkHeight(Sumatra)
+	require.NoError(err)
+	require.Equal(g.SumatraBlockHeight, h)
+	_, err = g.ForkHeight(Fork(100))
+	require.Error(err)
+}
+
+func TestForkSchedule(t *testing.T) {
+	require := require.New(t)
+	g := Default.Blockchain
+	schedule := g.ForkSchedule()
+	require.Len(schedule, int(ToBeEnabled))
+	require.Equal(ForkActivation{"pacific", g.PacificBlockHeight}, schedule[0])
+	require.Equal(ForkActivation{"sumatra", g.SumatraBlockHeight}, schedule[len(schedule)-1])
+	for i := 1; i < len(schedule); i++ {
+		require.LessOrEqual(schedule[i-1].Height, schedule[i].Height)
+	}
+
+	g.ToBeEnabledBlockHeight = g.SumatraBlockHeight + 1
+	schedule = g.ForkSchedule()
+	require.Len(schedule, int(ToBeEnabled)+1)
+	require.Equal(ForkActivation{"toBeEnabled", g.SumatraBlockHeight + 1}, schedule[len(schedule)-1])
+}
+
+func TestWithForkHeight(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	require.NoError(g.Validate())
+
+	tip := g.RedseaBlockHeight + 100
+	g1, err := g.WithForkHeight(Sumatra, tip+1)
+	require.NoError(err)
+	require.True(g1.IsSumatra(tip + 1))
+	require.False(g1.IsSumatra(tip))
+	// the original genesis is not modified
+	require.Equal(TestDefault().SumatraBlockHeight, g.SumatraBlockHeight)
+	g1.InitBalanceMap["io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6"] = "1"
+	_, ok := g.InitBalanceMap["io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6"]
+	require.False(ok)
+
+	// break the monotonicity
+	_, err = g.WithForkHeight(Sumatra, g.RedseaBlockHeight-1)
+	require.ErrorContains(err, "sumatra height")
+	_, err = g.WithForkHeight(Fork(100), 1)
+	require.Error(err)
+
+	g2, err := g.WithForkHeights(map[Fork]uint64{
+		Redsea:  tip,
+		Sumatra: tip + 1,
+	})
+	require.NoError(err)
+	require.Equal(tip, g2.RedseaBlockHeight)
+	require.Equal(tip+1, g2.SumatraBlockHeight)
+	_, err = g.WithForkHeights(map[Fork]uint64{
+		Redsea:  tip + 1,
+		Sumatra: tip,
+	})
+	require.Error(err)
+}
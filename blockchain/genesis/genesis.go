@@ -6,9 +6,15 @@
 package genesis
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
 	"math"
 	"math/big"
+	"os"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -17,6 +23,7 @@ import (
 	"go.uber.org/config"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
@@ -24,12 +31,42 @@ import (
 
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/test/identityset"
 )
 
-// Default contains the default genesis config
+const (
+	_mainnetChainName = "mainnet"
+	_mainnetChainID   = 1
+	_testnetChainName = "testnet"
+	_testnetChainID   = 2
+	_devnetChainName  = "devnet"
+	_devnetChainID    = 1337
+
+	// _identityHashVersion is the hash version since which the network identity is hashed
+	_identityHashVersion = 1
+
+	// _dardanellesBlockInterval is the nominal block interval since the Dardanelles fork. It is configured in the
+	// consensus config of the node rather than the genesis, see consensusfsm.DefaultDardanellesUpgradeConfig.
+	_dardanellesBlockInterval = 5 * time.Second
+)
+
+const (
+	// SystemStakingContractName is the name of the system staking contract in the system contracts
+	SystemStakingContractName = "systemStaking"
+	// SystemSGDContractName is the name of the system sgd contract in the system contracts
+	SystemSGDContractName = "systemSGD"
+)
+
+// Default contains the default genesis config. It is shared by the whole process and its maps and slices, e.g.,
+// InitBalanceMap, are not copied on assignment, so it must not be mutated.
+//
+// Deprecated: use GetDefault, which returns a deep copy that is safe to modify.
 var Default = defaultConfig()
 
+// ErrGenesisHashMismatch indicates the hash of the loaded genesis config doesn't match the expected one
+var ErrGenesisHashMismatch = errors.New("genesis hash mismatch")
+
 var (
 	_genesisTs     int64
 	_loadGenesisTs sync.Once
@@ -43,8 +80,11 @@ func defaultConfig() Genesis {
 	return Genesis{
 		Blockchain: Blockchain{
 			Timestamp:               1546329600,
+			ChainName:               _mainnetChainName,
+			ChainID:                 _mainnetChainID,
 			BlockGasLimit:           20000000,
 			ActionGasLimit:          5000000,
+			MinGasPriceStr:          big.NewInt(unit.Qev).String(),
 			BlockInterval:           10 * time.Second,
 			NumSubEpochs:            2,
 			DardanellesNumSubEpochs: 30,
@@ -76,7 +116,8 @@ func defaultConfig() Genesis {
 			ToBeEnabledBlockHeight:  math.MaxUint64,
 		},
 		Account: Account{
-			InitBalanceMap: make(map[string]string),
+			InitBalanceMap:   make(map[string]string),
+			InitBalancesIotx: make(map[string]string),
 		},
 		Poll: Poll{
 			PollMode:                         "nativeMix",
@@ -87,6 +128,7 @@ func defaultConfig() Genesis {
 			UnproductiveDelegateMaxCacheSize: 20,
 			SystemStakingContractAddress:     "io1drde9f483guaetl3w3w6n6y7yv80f8fael7qme", // https://iotexscout.io/tx/8b899515d180d631abe8596b091380b0f42117122415393fa459c74c2bc5b6af
 			SystemStakingContractHeight:      24486464,
+			SystemContracts:                  []SystemContract{},
 		},
 		Rewarding: Rewarding{
 			InitBalanceStr:                 unit.ConvertIotxToRau(200000000).String(),
@@ -119,6 +161,18 @@ func defaultConfig() Genesis {
 	}
 }
 
+// GetDefault returns a deep copy of Default, which is safe to modify
+func GetDefault() Genesis {
+	return Default.Clone()
+}
+
+// DefaultConfig returns a deep copy of Default, which is safe to modify
+//
+// Deprecated: use GetDefault instead.
+func DefaultConfig() Genesis {
+	return GetDefault()
+}
+
 // TestDefault is the default genesis config for testing
 func TestDefault() Genesis {
 	ge := defaultConfig()
@@ -156,10 +210,20 @@ type (
 	Blockchain struct {
 		// Timestamp is the timestamp of the genesis block
 		Timestamp int64
+		// ChainName is the human-readable name of the network
+		ChainName string `yaml:"chainName"`
+		// ChainID is the ID of the network, which is taken from the chain ID in the node config if left as the default
+		ChainID uint32 `yaml:"chainID"`
+		// HashVersion is the version of the genesis hash. The network identity is hashed along with the genesis proto
+		// since version 1, so that the genesis hash of a network launched with version 0 doesn't change.
+		HashVersion uint32 `yaml:"hashVersion"`
 		// BlockGasLimit is the total gas limit could be consumed in a block
 		BlockGasLimit uint64 `yaml:"blockGasLimit"`
 		// ActionGasLimit is the per action gas limit cap
 		ActionGasLimit uint64 `yaml:"actionGasLimit"`
+		// MinGasPriceStr is the minimum gas price of the network in decimal string format, where an empty string means
+		// the default of 1 Qev
+		MinGasPriceStr string `yaml:"minGasPrice" schema:"amount"`
 		// BlockInterval is the interval between two blocks
 		BlockInterval time.Duration `yaml:"blockInterval"`
 		// NumSubEpochs is the number of sub epochs in one epoch of block production
@@ -253,7 +317,10 @@ type (
 	// Account contains the configs for account protocol
 	Account struct {
 		// InitBalanceMap is the address and initial balance mapping before the first block.
-		InitBalanceMap map[string]string `yaml:"initBalances"`
+		InitBalanceMap map[string]string `yaml:"initBalances" schema:"amount"`
+		// InitBalancesIotx is the address and initial balance in IOTX mapping, e.g., 1.5 for 1.5 IOTX, which is merged
+		// into InitBalanceMap upon loading. An address must not appear in both.
+		InitBalancesIotx map[string]string `yaml:"initBalancesIotx" schema:"iotx"`
 	}
 	// Poll contains the configs for poll protocol
 	Poll struct {
@@ -278,11 +345,11 @@ type (
 		// ConsortiumCommitteeCode is the code of consortiumCommittee contract
 		ConsortiumCommitteeContractCode string `yaml:"consortiumCommitteeContractCode"`
 		// VoteThreshold is the vote threshold amount in decimal string format
-		VoteThreshold string `yaml:"voteThreshold"`
+		VoteThreshold string `yaml:"voteThreshold" schema:"amount"`
 		// ScoreThreshold is the score threshold amount in decimal string format
-		ScoreThreshold string `yaml:"scoreThreshold"`
+		ScoreThreshold string `yaml:"scoreThreshold" schema:"amount"`
 		// SelfStakingThreshold is self-staking vote threshold amount in decimal string format
-		SelfStakingThreshold string `yaml:"selfStakingThreshold"`
+		SelfStakingThreshold string `yaml:"selfStakingThreshold" schema:"amount"`
 		// Delegates is a list of delegates with votes
 		Delegates []Delegate `yaml:"delegates"`
 		// ProbationEpochPeriod is a duration of probation after delegate's productivity is lower than threshold
@@ -290,7 +357,7 @@ type (
 		// ProbationIntensityRate is a intensity rate of probation range from [0, 100], where 100 is hard-probation
 		ProbationIntensityRate uint32 `yaml:"probationIntensityRate"`
 		// UnproductiveDelegateMaxCacheSize is a max cache size of upd which is stored into state DB (probationEpochPeriod <= UnproductiveDelegateMaxCacheSize)
-		UnproductiveDelegateMaxCacheSize uint64 `yaml:unproductiveDelegateMaxCacheSize`
+		UnproductiveDelegateMaxCacheSize uint64 `yaml:"unproductiveDelegateMaxCacheSize"`
 		// SystemStakingContractAddress is the address of system staking contract
 		SystemStakingContractAddress string `yaml:"systemStakingContractAddress"`
 		// SystemStakingContractHeight is the height of system staking contract
@@ -299,6 +366,21 @@ type (
 		SystemSGDContractAddress string `yaml:"systemSGDContractAddress"`
 		// SystemSGDContractHeight is the height of system sgd contract
 		SystemSGDContractHeight uint64 `yaml:"systemSGDContractHeight"`
+		// SystemContracts is a list of other system contracts. The system staking and sgd contracts are configured by
+		// the fields above, and looked up by SystemContract under the names SystemStakingContractName and
+		// SystemSGDContractName.
+		SystemContracts []SystemContract `yaml:"systemContracts"`
+	}
+	// SystemContract defines a pre-deployed system contract
+	SystemContract struct {
+		// Name is the unique name of the contract
+		Name string `yaml:"name"`
+		// Address is the address of the contract
+		Address string `yaml:"address"`
+		// Height is the height since which the contract is active
+		Height uint64 `yaml:"height"`
+		// Code is the hex-encoded code of the contract, which is optional
+		Code string `yaml:"code"`
 	}
 	// Delegate defines a delegate with address and votes
 	Delegate struct {
@@ -307,26 +389,26 @@ type (
 		// RewardAddrStr is the address who will get the reward when operator produces blocks
 		RewardAddrStr string `yaml:"rewardAddr"`
 		// VotesStr is the score for the operator to rank and weight for rewardee to split epoch reward
-		VotesStr string `yaml:"votes"`
+		VotesStr string `yaml:"votes" schema:"amount"`
 	}
 	// Rewarding contains the configs for rewarding protocol
 	Rewarding struct {
 		// InitBalanceStr is the initial balance of the rewarding protocol in decimal string format
-		InitBalanceStr string `yaml:"initBalance"`
+		InitBalanceStr string `yaml:"initBalance" schema:"amount"`
 		// BlockReward is the block reward amount in decimal string format
-		BlockRewardStr string `yaml:"blockReward"`
+		BlockRewardStr string `yaml:"blockReward" schema:"amount"`
 		// DardanellesBlockReward is the block reward amount starts from dardanelles height in decimal string format
-		DardanellesBlockRewardStr string `yaml:"dardanellesBlockReward"`
+		DardanellesBlockRewardStr string `yaml:"dardanellesBlockReward" schema:"amount"`
 		// EpochReward is the epoch reward amount in decimal string format
-		EpochRewardStr string `yaml:"epochReward"`
+		EpochRewardStr string `yaml:"epochReward" schema:"amount"`
 		// AleutianEpochRewardStr is the epoch reward amount in decimal string format after aleutian fork
-		AleutianEpochRewardStr string `yaml:"aleutianEpochReward"`
+		AleutianEpochRewardStr string `yaml:"aleutianEpochReward" schema:"amount"`
 		// NumDelegatesForEpochReward is the number of top candidates that will share a epoch reward
 		NumDelegatesForEpochReward uint64 `yaml:"numDelegatesForEpochReward"`
 		// ExemptAddrStrsFromEpochReward is the list of addresses in encoded string format that exempt from epoch reward
 		ExemptAddrStrsFromEpochReward []string `yaml:"exemptAddrsFromEpochReward"`
 		// FoundationBonusStr is the bootstrap bonus in decimal string format
-		FoundationBonusStr string `yaml:"foundationBonus"`
+		FoundationBonusStr string `yaml:"foundationBonus" schema:"amount"`
 		// NumDelegatesForFoundationBonus is the number of top candidate that will get the bootstrap bonus
 		NumDelegatesForFoundationBonus uint64 `yaml:"numDelegatesForFoundationBonus"`
 		// FoundationBonusLastEpoch is the last epoch number that bootstrap bonus will be granted
@@ -343,7 +425,7 @@ type (
 		VoteWeightCalConsts   VoteWeightCalConsts  `yaml:"voteWeightCalConsts"`
 		RegistrationConsts    RegistrationConsts   `yaml:"registrationConsts"`
 		WithdrawWaitingPeriod time.Duration        `yaml:"withdrawWaitingPeriod"`
-		MinStakeAmount        string               `yaml:"minStakeAmount"`
+		MinStakeAmount        string               `yaml:"minStakeAmount" schema:"amount"`
 		BootstrapCandidates   []BootstrapCandidate `yaml:"bootstrapCandidates"`
 	}
 
@@ -356,8 +438,8 @@ type (
 
 	// RegistrationConsts contains the configs for candidate registration
 	RegistrationConsts struct {
-		Fee          string `yaml:"fee"`
-		MinSelfStake string `yaml:"minSelfStake"`
+		Fee          string `yaml:"fee" schema:"amount"`
+		MinSelfStake string `yaml:"minSelfStake" schema:"amount"`
 	}
 
 	// BootstrapCandidate is the candidate data need to be provided to bootstrap candidate.
@@ -366,20 +448,43 @@ type (
 		OperatorAddress   string `yaml:"operatorAddress"`
 		RewardAddress     string `yaml:"rewardAddress"`
 		Name              string `yaml:"name"`
-		SelfStakingTokens string `yaml:"selfStakingTokens"`
+		SelfStakingTokens string `yaml:"selfStakingTokens" schema:"amount"`
 	}
 )
 
 // New constructs a genesis config. It loads the default values, and could be overwritten by values defined in the yaml
-// config files
+// config files, which could be overwritten by the environment variables in turn. See EnvPrefix for the naming of the
+// environment variables. The yaml config files are taken literally, i.e., ${VAR} is not expanded. If genesisPath is not an
+// existing file but the name of a built-in profile, e.g., testnet, the profile is loaded instead of the mainnet config.
+// The amounts, which are decimal strings, could be written as numbers in the yaml config file as well. The deprecated
+// keys, e.g., rewarding.bootstrapBonus for rewarding.foundationBonus, are still recognized with a warning. The staking
+// config is validated by Staking.Validate, while the full validation is left to NewStrict or Validate.
 func New(genesisPath string) (Genesis, error) {
 	def := defaultConfig()
+	if genesisPath != "" {
+		if _, err := os.Stat(genesisPath); os.IsNotExist(err) {
+			if profile, err := ByName(genesisPath); err == nil {
+				def, genesisPath = profile, ""
+			}
+		}
+	}
 
+	// only the environment overrides are expanded, the defaults and the yaml file are taken as they are
+	defSrc, err := yaml.Marshal(def)
+	if err != nil {
+		return Genesis{}, errors.Wrap(err, "failed to serialize default genesis")
+	}
 	opts := make([]config.YAMLOption, 0)
-	opts = append(opts, config.Static(def))
+	opts = append(opts, config.RawSource(bytes.NewReader(defSrc)))
 	if genesisPath != "" {
-		opts = append(opts, config.File(genesisPath))
+		src, err := os.ReadFile(genesisPath)
+		if err != nil {
+			return Genesis{}, errors.Wrap(err, "failed to read genesis yaml")
+		}
+		// an amount could be given as a number rather than a string
+		opts = append(opts, config.RawSource(bytes.NewReader(quoteNumericStrings(renameDeprecatedKeys(src)))))
 	}
+	opts = append(opts, config.Source(bytes.NewReader(envOverrides(os.LookupEnv))), config.Expand(os.LookupEnv))
 	yaml, err := config.NewYAML(opts...)
 	if err != nil {
 		return Genesis{}, errors.Wrap(err, "error when constructing a genesis in yaml")
@@ -389,9 +494,116 @@ func New(genesisPath string) (Genesis, error) {
 	if err := yaml.Get(config.Root).Populate(&genesis); err != nil {
 		return Genesis{}, errors.Wrap(err, "failed to unmarshal yaml genesis to struct")
 	}
+	if err := genesis.mergeInitBalancesIotx(); err != nil {
+		return Genesis{}, err
+	}
+	// a misconfigured staking, e.g., a zero withdraw waiting period, is an economic bug rather than a typo
+	if err := genesis.Staking.Validate(); err != nil {
+		return Genesis{}, errors.Wrap(err, "invalid staking config")
+	}
 	return genesis, nil
 }
 
+// NewStrict constructs a genesis config as New does, and validates it. An unrecognized key in the yaml config file,
+// e.g., a misspelled fork height, fails the construction with ErrUnknownKeys naming the full paths of the keys.
+func NewStrict(genesisPath string) (Genesis, error) {
+	if genesisPath != "" {
+		src, err := os.ReadFile(genesisPath)
+		if err != nil {
+			return Genesis{}, errors.Wrap(err, "failed to read genesis yaml")
+		}
+		if err := checkUnknownKeys(renameDeprecatedKeys(src)); err != nil {
+			return Genesis{}, err
+		}
+	}
+	g, err := New(genesisPath)
+	if err != nil {
+		return Genesis{}, err
+	}
+	if err := g.Validate(); err != nil {
+		return Genesis{}, err
+	}
+	return g, nil
+}
+
+// NewVerified constructs a genesis config as New does, and returns ErrGenesisHashMismatch if its hash doesn't match
+// the expected one, which prevents a node from joining a wrong network
+func NewVerified(genesisPath string, expected hash.Hash256) (Genesis, error) {
+	g, err := New(genesisPath)
+	if err != nil {
+		return Genesis{}, err
+	}
+	if h := g.Hash(); h != expected {
+		return Genesis{}, errors.Wrapf(ErrGenesisHashMismatch, "expected %x, got %x", expected, h)
+	}
+	return g, nil
+}
+
+// Validate validates the genesis config
+func (g *Genesis) Validate() error {
+	if err := g.Blockchain.validateForkHeights(); err != nil {
+		return errors.Wrap(err, "invalid fork heights")
+	}
+	if g.BlockInterval <= 0 {
+		return errors.Errorf("invalid block interval %s", g.BlockInterval)
+	}
+	if g.MinGasPriceStr != "" {
+		if _, err := parseAmount("min gas price", g.MinGasPriceStr); err != nil {
+			return err
+		}
+	}
+	if err := g.Rewarding.Validate(); err != nil {
+		return errors.Wrap(err, "invalid rewarding config")
+	}
+	if err := g.Poll.Validate(); err != nil {
+		return errors.Wrap(err, "invalid poll config")
+	}
+	if err := g.Staking.Validate(); err != nil {
+		return errors.Wrap(err, "invalid staking config")
+	}
+	if err := g.Account.EachInitBalance(func(address.Address, *big.Int) error { return nil }); err != nil {
+		return errors.Wrap(err, "invalid account config")
+	}
+	if _, err := g.Account.initBalancesIotxInRau(); err != nil {
+		return errors.Wrap(err, "invalid account config")
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the genesis config
+func (g Genesis) Clone() Genesis {
+	clone := g
+	if g.InitBalanceMap != nil {
+		clone.InitBalanceMap = make(map[string]string, len(g.InitBalanceMap))
+		for addr, balance := range g.InitBalanceMap {
+			clone.InitBalanceMap[addr] = balance
+		}
+	}
+	if g.InitBalancesIotx != nil {
+		clone.InitBalancesIotx = make(map[string]string, len(g.InitBalancesIotx))
+		for addr, balance := range g.InitBalancesIotx {
+			clone.InitBalancesIotx[addr] = balance
+		}
+	}
+	if g.Delegates != nil {
+		clone.Delegates = make([]Delegate, len(g.Delegates))
+		copy(clone.Delegates, g.Delegates)
+	}
+	if g.ExemptAddrStrsFromEpochReward != nil {
+		clone.ExemptAddrStrsFromEpochReward = make([]string, len(g.ExemptAddrStrsFromEpochReward))
+		copy(clone.ExemptAddrStrsFromEpochReward, g.ExemptAddrStrsFromEpochReward)
+	}
+	if g.BootstrapCandidates != nil {
+		clone.BootstrapCandidates = make([]BootstrapCandidate, len(g.BootstrapCandidates))
+		copy(clone.BootstrapCandidates, g.BootstrapCandidates)
+	}
+	if g.SystemContracts != nil {
+		clone.SystemContracts = make([]SystemContract, len(g.SystemContracts))
+		copy(clone.SystemContracts, g.SystemContracts)
+	}
+	return clone
+}
+
 // SetGenesisTimestamp sets the genesis timestamp
 func SetGenesisTimestamp(ts int64) {
 	_loadGenesisTs.Do(func() {
@@ -404,13 +616,14 @@ func Timestamp() int64 {
 	return atomic.LoadInt64(&_genesisTs)
 }
 
-// Hash is the hash of genesis config
-func (g *Genesis) Hash() hash.Hash256 {
+// Proto returns the genesis config in the canonical proto which Hash is computed upon. The network identity is not
+// covered by the proto, and the init balances are sorted by address.
+func (g *Genesis) Proto() *iotextypes.Genesis {
 	gbProto := iotextypes.GenesisBlockchain{
 		Timestamp:             g.Timestamp,
 		BlockGasLimit:         g.BlockGasLimit,
 		ActionGasLimit:        g.ActionGasLimit,
-		BlockInterval:         g.BlockInterval.Nanoseconds(),
+		BlockInterval:         g.BlockIntervalNanos(),
 		NumSubEpochs:          g.NumSubEpochs,
 		NumDelegates:          g.NumDelegates,
 		NumCandidateDelegates: g.NumCandidateDelegates,
@@ -462,19 +675,308 @@ func (g *Genesis) Hash() hash.Hash256 {
 		ProductivityThreshold:          g.ProductivityThreshold,
 	}
 
-	gProto := iotextypes.Genesis{
+	return &iotextypes.Genesis{
 		Blockchain: &gbProto,
 		Account:    &aProto,
 		Poll:       &pProto,
 		Rewarding:  &rProto,
 	}
-	b, err := proto.Marshal(&gProto)
+}
+
+// FromProto converts the genesis proto into a genesis config. The fields not covered by the proto, including the
+// network identity and the fork heights, take the values of the default config, so the hash of the genesis config
+// equals the hash of the original one only if the network identity is the one of mainnet, use FromIdentifiedProto
+// otherwise. The genesis config is not validated other than the init balances, while the full validation is left to
+// Validate.
+func FromProto(p *iotextypes.Genesis) (Genesis, error) {
+	if p.GetBlockchain() == nil || p.GetAccount() == nil || p.GetPoll() == nil || p.GetRewarding() == nil {
+		return Genesis{}, errors.New("genesis proto is incomplete")
+	}
+	g := GetDefault()
+	bc := p.GetBlockchain()
+	g.Timestamp = bc.GetTimestamp()
+	g.BlockGasLimit = bc.GetBlockGasLimit()
+	g.ActionGasLimit = bc.GetActionGasLimit()
+	g.BlockInterval = time.Duration(bc.GetBlockInterval())
+	g.NumSubEpochs = bc.GetNumSubEpochs()
+	g.NumDelegates = bc.GetNumDelegates()
+	g.NumCandidateDelegates = bc.GetNumCandidateDelegates()
+	g.TimeBasedRotation = bc.GetTimeBasedRotation()
+
+	addrs, balances := p.GetAccount().GetInitBalanceAddrs(), p.GetAccount().GetInitBalances()
+	if len(addrs) != len(balances) {
+		return Genesis{}, errors.Errorf("%d init balance addresses mismatch %d init balances", len(addrs), len(balances))
+	}
+	g.InitBalanceMap = make(map[string]string, len(addrs))
+	for i, addr := range addrs {
+		if _, ok := g.InitBalanceMap[addr]; ok {
+			return Genesis{}, errors.Errorf("duplicate init balance of %s", addr)
+		}
+		g.InitBalanceMap[addr] = balances[i]
+	}
+	g.InitBalancesIotx = make(map[string]string)
+
+	poll := p.GetPoll()
+	g.EnableGravityChainVoting = poll.GetEnableGravityChainVoting()
+	g.GravityChainStartHeight = poll.GetGravityChainStartHeight()
+	g.RegisterContractAddress = poll.GetRegisterContractAddress()
+	g.StakingContractAddress = poll.GetStakingContractAddress()
+	g.VoteThreshold = poll.GetVoteThreshold()
+	g.ScoreThreshold = poll.GetScoreThreshold()
+	g.SelfStakingThreshold = poll.GetSelfStakingThreshold()
+	g.Delegates = make([]Delegate, 0, len(poll.GetDelegates()))
+	for _, d := range poll.GetDelegates() {
+		g.Delegates = append(g.Delegates, Delegate{
+			OperatorAddrStr: d.GetOperatorAddr(),
+			RewardAddrStr:   d.GetRewardAddr(),
+			VotesStr:        d.GetVotes(),
+		})
+	}
+
+	r := p.GetRewarding()
+	g.InitBalanceStr = r.GetInitBalance()
+	g.BlockRewardStr = r.GetBlockReward()
+	g.EpochRewardStr = r.GetEpochReward()
+	g.NumDelegatesForEpochReward = r.GetNumDelegatesForEpochReward()
+	g.FoundationBonusStr = r.GetFoundationBonus()
+	g.NumDelegatesForFoundationBonus = r.GetNumDelegatesForFoundationBonus()
+	g.FoundationBonusLastEpoch = r.GetFoundationBonusLastEpoch()
+	g.ProductivityThreshold = r.GetProductivityThreshold()
+	return g, nil
+}
+
+// IdentifiedProto is the genesis config in the canonical proto along with the network identity, which is not covered
+// by the proto but hashed alongside it since hash version 1
+type IdentifiedProto struct {
+	Genesis     *iotextypes.Genesis
+	ChainID     uint32
+	ChainName   string
+	HashVersion uint32
+}
+
+// IdentifiedProto returns the genesis config in the canonical proto along with the network identity
+func (g *Genesis) IdentifiedProto() *IdentifiedProto {
+	return &IdentifiedProto{
+		Genesis:     g.Proto(),
+		ChainID:     g.ChainID,
+		ChainName:   g.ChainName,
+		HashVersion: g.HashVersion,
+	}
+}
+
+// FromIdentifiedProto converts the genesis proto along with the network identity into a genesis config, whose hash
+// equals the hash of the original one on any network
+func FromIdentifiedProto(p *IdentifiedProto) (Genesis, error) {
+	if p.ChainID == 0 || p.ChainName == "" {
+		return Genesis{}, errors.Errorf("invalid network identity %d %q", p.ChainID, p.ChainName)
+	}
+	g, err := FromProto(p.Genesis)
+	if err != nil {
+		return Genesis{}, err
+	}
+	g.ChainID, g.ChainName, g.HashVersion = p.ChainID, p.ChainName, p.HashVersion
+	return g, nil
+}
+
+// Hash is the hash of genesis config
+func (g *Genesis) Hash() hash.Hash256 {
+	b, err := proto.Marshal(g.Proto())
 	if err != nil {
 		log.L().Panic("Error when marshaling genesis proto", zap.Error(err))
 	}
+	// the network identity is hashed only since hash version 1, so that the hash of a genesis config created prior to
+	// the introduction of network identity doesn't change
+	if g.HashVersion >= _identityHashVersion {
+		b = binary.BigEndian.AppendUint32(b, g.ChainID)
+		b = append(b, g.ChainName...)
+	}
 	return hash.Hash256b(b)
 }
 
+// HashHex returns the hash of the genesis config in lowercase hex. The hash is computed upon each call rather than
+// cached, as a genesis config could still be modified after loading, e.g., by WithForkHeights.
+func (g *Genesis) HashHex() string {
+	h := g.Hash()
+	return hex.EncodeToString(h[:])
+}
+
+// LogFields returns the key values of the genesis config as log fields, including the hash, the network identity, the
+// fork heights, and the number and the total amount of the initial balances rather than the balances themselves
+func (g *Genesis) LogFields() []zap.Field {
+	fields := []zap.Field{
+		zap.String("hash", g.HashHex()),
+		zap.String("chainName", g.ChainName),
+		zap.Uint32("chainID", g.ChainID),
+		zap.Int64("timestamp", g.Timestamp),
+		zap.Duration("blockInterval", g.BlockInterval),
+		zap.Uint64("numDelegates", g.NumDelegates),
+		zap.Uint64("numSubEpochs", g.NumSubEpochs),
+		zap.Uint64("dardanellesNumSubEpochs", g.DardanellesNumSubEpochs),
+	}
+	for _, f := range Forks() {
+		fields = append(fields, zap.Uint64(f.String()+"Height", *g.forkHeight(f)))
+	}
+	total := big.NewInt(0)
+	if err := g.EachInitBalance(func(_ address.Address, amount *big.Int) error {
+		total.Add(total, amount)
+		return nil
+	}); err != nil {
+		return append(fields, zap.Int("numInitBalances", len(g.InitBalanceMap)), zap.NamedError("initBalancesError", err))
+	}
+	return append(fields, zap.Int("numInitBalances", len(g.InitBalanceMap)), zap.String("initTotalSupply", total.String()))
+}
+
+// ValidateTotalSupply returns an error if the initial total supply, i.e., the sum of the init balances, including the
+// ones in IOTX not merged yet, and the init balance of the rewarding fund, exceeds cap. The error reports the actual
+// total supply.
+func (g *Genesis) ValidateTotalSupply(cap *big.Int) error {
+	if cap == nil || cap.Sign() < 0 {
+		return errors.Errorf("invalid total supply cap %v", cap)
+	}
+	total, err := parseAmount("rewarding init balance", g.InitBalanceStr)
+	if err != nil {
+		return err
+	}
+	if err := g.EachInitBalance(func(_ address.Address, amount *big.Int) error {
+		total.Add(total, amount)
+		return nil
+	}); err != nil {
+		return err
+	}
+	balances, err := g.initBalancesIotxInRau()
+	if err != nil {
+		return err
+	}
+	for _, amount := range balances {
+		total.Add(total, amount)
+	}
+	if total.Cmp(cap) > 0 {
+		return errors.Errorf("initial total supply %s exceeds cap %s", total, cap)
+	}
+	return nil
+}
+
+// IsSystemStakingEnabled checks whether the system staking contract is configured and deployed at height
+func (g *Genesis) IsSystemStakingEnabled(height uint64) bool {
+	return g.SystemStakingContractAddress != "" && height >= g.SystemStakingContractHeight
+}
+
+// IsSystemSGDEnabled checks whether the system sgd contract is configured and deployed at height
+func (g *Genesis) IsSystemSGDEnabled(height uint64) bool {
+	return g.SystemSGDActive(height)
+}
+
+// SystemContract returns the system contract of name, including the system staking and sgd contracts configured by
+// the legacy fields under the names SystemStakingContractName and SystemSGDContractName
+func (g *Genesis) SystemContract(name string) (SystemContract, bool) {
+	for _, c := range g.allSystemContracts() {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return SystemContract{}, false
+}
+
+// allSystemContracts returns the system contracts with the ones configured by the legacy fields ahead
+func (p *Poll) allSystemContracts() []SystemContract {
+	contracts := make([]SystemContract, 0, len(p.SystemContracts)+2)
+	if p.SystemStakingContractAddress != "" {
+		contracts = append(contracts, SystemContract{
+			Name:    SystemStakingContractName,
+			Address: p.SystemStakingContractAddress,
+			Height:  p.SystemStakingContractHeight,
+		})
+	}
+	if p.SystemSGDContractAddress != "" {
+		contracts = append(contracts, SystemContract{
+			Name:    SystemSGDContractName,
+			Address: p.SystemSGDContractAddress,
+			Height:  p.SystemSGDContractHeight,
+		})
+	}
+	return append(contracts, p.SystemContracts...)
+}
+
+// ContractBucketWeight returns the weighted votes of a contract staking bucket at height. The votes of a contract
+// staking bucket are not weighted before Redsea, and are weighted as native staking buckets since Redsea.
+func (g *Genesis) ContractBucketWeight(amount *big.Int, durationDays uint32, autoStake bool, height uint64) *big.Int {
+	if !g.IsRedsea(height) {
+		return new(big.Int).Set(amount)
+	}
+	return g.VoteWeightCalConsts.VoteWeight(amount, time.Duration(durationDays)*24*time.Hour, autoStake, false)
+}
+
+// VoteWeight calculates the weighted votes of a bucket of amount staked for duration, which is amount multiplied by
+// Weight
+func (c VoteWeightCalConsts) VoteWeight(amount *big.Int, duration time.Duration, autoStake, selfStake bool) *big.Int {
+	weight := c.Weight(duration, autoStake, selfStake)
+	weightedAmount, _ := new(big.Float).Mul(new(big.Float).SetInt(amount), big.NewFloat(weight)).Int(nil)
+	return weightedAmount
+}
+
+// Weight returns the vote weight multiplier of a bucket staked for duration. The duration is rounded up to days, and
+// the multiplier is
+//
+//	1 + log(days * (1 + AutoStake if auto-stake else 0)) / log(DurationLg) / 100
+//
+// for a duration of at least one day, and 1 otherwise. A self-stake bucket which is auto-staked for at least 91 days
+// gets a bonus, which multiplies the multiplier by SelfStake.
+func (c VoteWeightCalConsts) Weight(duration time.Duration, autoStake, selfStake bool) float64 {
+	var days uint32
+	if remainingTime := duration.Seconds(); remainingTime > 0 {
+		days = uint32(math.Ceil(remainingTime / 86400))
+	}
+	weight := c.durationMultiplier(days, autoStake)
+	if selfStake && autoStake && duration >= time.Duration(91)*24*time.Hour {
+		// self-stake extra bonus requires enable auto-stake for at least 3 months
+		weight *= c.SelfStake
+	}
+	return weight
+}
+
+// durationMultiplier returns the multiplier of staking for days, the auto-stake bonus counts as extra days
+func (c VoteWeightCalConsts) durationMultiplier(days uint32, autoStake bool) float64 {
+	if days == 0 {
+		return 1
+	}
+	var m float64
+	if autoStake {
+		m = c.AutoStake
+	}
+	return 1 + math.Log(float64(days)*(1+m))/math.Log(c.DurationLg)/100
+}
+
+// NetworkName returns the name of the network, which is derived from the chain ID if the chain name is not set
+func (g *Blockchain) NetworkName() string {
+	if g.ChainName != "" {
+		return g.ChainName
+	}
+	switch g.ChainID {
+	case _mainnetChainID:
+		return _mainnetChainName
+	case _testnetChainID:
+		return _testnetChainName
+	case _devnetChainID:
+		return _devnetChainName
+	default:
+		return "unknown"
+	}
+}
+
+// IsValidChainID checks whether an action signed with chain ID id is valid at height. Any chain ID is accepted before
+// Midway, the chain ID of the network or the default zero is accepted since Midway, and only the chain ID of the
+// network is accepted since Quebec.
+func (g *Blockchain) IsValidChainID(id uint32, height uint64) bool {
+	switch {
+	case g.IsQuebec(height):
+		return id == g.ChainID
+	case g.IsMidway(height):
+		return id == g.ChainID || id == 0
+	default:
+		return true
+	}
+}
+
 func (g *Blockchain) isPost(targetHeight, height uint64) bool {
 	return height >= targetHeight
 }
@@ -499,6 +1001,50 @@ func (g *Blockchain) IsCook(height uint64) bool {
 	return g.isPost(g.CookBlockHeight, height)
 }
 
+// NumSubEpochsByHeight returns the number of sub epochs in an epoch at height, which is DardanellesNumSubEpochs since
+// Dardanelles and NumSubEpochs before, the same as rolldpos.Protocol.NumSubEpochs
+func (g *Blockchain) NumSubEpochsByHeight(height uint64) uint64 {
+	if g.IsDardanelles(height) {
+		return g.DardanellesNumSubEpochs
+	}
+	return g.NumSubEpochs
+}
+
+// NumBlocksByEpoch returns the number of blocks in the epoch of height. Note that the epoch which Dardanelles height
+// falls in already has the Dardanelles length, since the epochs since Dardanelles are counted from its start height.
+func (g *Blockchain) NumBlocksByEpoch(height uint64) uint64 {
+	legacyEpochLen := g.NumDelegates * g.NumSubEpochs
+	var dardanellesEpochHeight uint64
+	if g.DardanellesBlockHeight > 0 && legacyEpochLen > 0 {
+		dardanellesEpochHeight = (g.DardanellesBlockHeight-1)/legacyEpochLen*legacyEpochLen + 1
+	}
+	if height >= dardanellesEpochHeight {
+		return g.NumDelegates * g.DardanellesNumSubEpochs
+	}
+	return legacyEpochLen
+}
+
+// ProposerSlot returns the index of the proposer of the block at height in a proposer list of the size delegates, as
+// the consensus picks it in the first round of the height. -1 is returned for an empty proposer list.
+func (g *Blockchain) ProposerSlot(height uint64, delegates int) int {
+	return g.ProposerSlotAtRound(height, 0, delegates)
+}
+
+// ProposerSlotAtRound returns the index of the proposer as ProposerSlot does, in the round of the height. If
+// TimeBasedRotation is enabled, the slot rotates to the next delegate each time a round times out, so a height alone
+// doesn't determine the proposer once the first round fails, and the round is required. Otherwise the slot is fixed by
+// the height.
+func (g *Blockchain) ProposerSlotAtRound(height uint64, round uint32, delegates int) int {
+	if delegates <= 0 {
+		return -1
+	}
+	idx := height
+	if g.TimeBasedRotation {
+		idx += uint64(round)
+	}
+	return int(idx % uint64(delegates))
+}
+
 // IsDardanelles checks whether height is equal to or larger than dardanelles height
 func (g *Blockchain) IsDardanelles(height uint64) bool {
 	return g.isPost(g.DardanellesBlockHeight, height)
@@ -594,30 +1140,246 @@ func (g *Blockchain) IsToBeEnabled(height uint64) bool {
 	return g.isPost(g.ToBeEnabledBlockHeight, height)
 }
 
+// EVMRules are the Ethereum forks and EVM features enabled at a height
+type EVMRules struct {
+	// IstanbulEnabled is true since Iceland, which enables Istanbul and MuirGlacier
+	IstanbulEnabled bool
+	// BerlinEnabled is true since Okhotsk, which enables Berlin and London together
+	BerlinEnabled bool
+	// LondonEnabled is true since Okhotsk
+	LondonEnabled bool
+	// ShanghaiEnabled is true since Sumatra, which enables Merge and Shanghai
+	ShanghaiEnabled bool
+	// ChainIDOpcode is true since Iceland, from which the CHAINID opcode returns the EVM network ID
+	ChainIDOpcode bool
+}
+
+// EVMRulesAt returns the Ethereum forks enabled at height, which are mapped to the IoTeX forks the same way as the
+// chain config of the EVM, so that an adapter could configure the EVM without checking the IoTeX forks one by one
+func (g *Blockchain) EVMRulesAt(height uint64) EVMRules {
+	return EVMRules{
+		IstanbulEnabled: g.IsIceland(height),
+		BerlinEnabled:   g.IsOkhotsk(height),
+		LondonEnabled:   g.IsOkhotsk(height),
+		ShanghaiEnabled: g.IsSumatra(height),
+		ChainIDOpcode:   g.IsIceland(height),
+	}
+}
+
+// BlockGasLimitAt returns the total gas limit could be consumed in a block at height
+func (g *Blockchain) BlockGasLimitAt(height uint64) uint64 {
+	return g.BlockGasLimit
+}
+
+// ActionGasLimitAt returns the gas limit could be consumed by an action at height
+func (g *Blockchain) ActionGasLimitAt(height uint64) uint64 {
+	return g.ActionGasLimit
+}
+
+// BlockIntervalNanos returns the block interval in nanoseconds
+func (g *Blockchain) BlockIntervalNanos() int64 {
+	return g.BlockInterval.Nanoseconds()
+}
+
+// GenesisTime returns the time of the genesis block in UTC
+func (g *Blockchain) GenesisTime() time.Time {
+	return time.Unix(g.Timestamp, 0).UTC()
+}
+
+// HeightToTime returns the nominal time of the block at height, which is the genesis time plus the block intervals
+// before and since the Dardanelles fork. It is an approximation for scheduling and display, the actual timestamp in
+// the block header could differ because of delays and skipped rounds.
+func (g *Blockchain) HeightToTime(height uint64) time.Time {
+	var preDardanelles uint64
+	if g.DardanellesBlockHeight > 0 {
+		preDardanelles = g.DardanellesBlockHeight - 1
+	}
+	if height <= preDardanelles {
+		return g.GenesisTime().Add(time.Duration(height) * g.BlockInterval)
+	}
+	return g.GenesisTime().
+		Add(time.Duration(preDardanelles) * g.BlockInterval).
+		Add(time.Duration(height-preDardanelles) * _dardanellesBlockInterval)
+}
+
+// MinGasPrice returns the minimum gas price of the network, which is 1 Qev if not configured
+func (g *Blockchain) MinGasPrice() *big.Int {
+	if g.MinGasPriceStr == "" {
+		return big.NewInt(unit.Qev)
+	}
+	val, ok := new(big.Int).SetString(g.MinGasPriceStr, 10)
+	if !ok {
+		log.S().Panicf("Error when casting min gas price string %s into big int", g.MinGasPriceStr)
+	}
+	return val
+}
+
+// CheckActionGas returns an error if gas exceeds the action gas limit
+func (g *Blockchain) CheckActionGas(gas uint64) error {
+	if gas > g.ActionGasLimit {
+		return errors.Errorf("gas %d exceeds action gas limit %d", gas, g.ActionGasLimit)
+	}
+	return nil
+}
+
+// CheckBlockGas returns an error if the total gas exceeds the block gas limit
+func (g *Blockchain) CheckBlockGas(total uint64) error {
+	if total > g.BlockGasLimit {
+		return errors.Errorf("total gas %d exceeds block gas limit %d", total, g.BlockGasLimit)
+	}
+	return nil
+}
+
 // InitBalances returns the address that have initial balances and the corresponding amounts. The i-th amount is the
 // i-th address' balance.
 func (a *Account) InitBalances() ([]address.Address, []*big.Int) {
+	addrs := make([]address.Address, 0, len(a.InitBalanceMap))
+	amounts := make([]*big.Int, 0, len(a.InitBalanceMap))
+	if err := a.EachInitBalance(func(addr address.Address, amount *big.Int) error {
+		addrs = append(addrs, addr)
+		amounts = append(amounts, amount)
+		return nil
+	}); err != nil {
+		log.L().Panic("Error when decoding the account protocol init balances.", zap.Error(err))
+	}
+	return addrs, amounts
+}
+
+// EachInitBalance calls fn with each address that has initial balance and the corresponding amount, in the order of
+// the address, without materializing the whole list. It stops and returns the error once fn returns one.
+func (a *Account) EachInitBalance(fn func(addr address.Address, amount *big.Int) error) error {
 	// Make the list always be ordered
-	addrStrs := make([]string, 0)
+	addrStrs := make([]string, 0, len(a.InitBalanceMap))
 	for addrStr := range a.InitBalanceMap {
 		addrStrs = append(addrStrs, addrStr)
 	}
 	sort.Strings(addrStrs)
-	addrs := make([]address.Address, 0)
-	amounts := make([]*big.Int, 0)
 	for _, addrStr := range addrStrs {
 		addr, err := address.FromString(addrStr)
 		if err != nil {
-			log.L().Panic("Error when decoding the account protocol init balance address from string.", zap.Error(err))
+			return errors.Wrapf(err, "failed to decode init balance address %s", addrStr)
 		}
-		addrs = append(addrs, addr)
-		amount, ok := new(big.Int).SetString(a.InitBalanceMap[addrStr], 10)
-		if !ok {
-			log.S().Panicf("Error when casting init balance string %s into big int", a.InitBalanceMap[addrStr])
+		amount, err := parseAmount("init balance of "+addrStr, a.InitBalanceMap[addrStr])
+		if err != nil {
+			return err
+		}
+		if err := fn(addr, amount); err != nil {
+			return err
 		}
-		amounts = append(amounts, amount)
 	}
-	return addrs, amounts
+	return nil
+}
+
+// initBalancesIotxInRau returns the initial balances in IOTX converted into Rau, and an error if an address has the
+// initial balances in both Rau and IOTX
+func (a *Account) initBalancesIotxInRau() (map[string]*big.Int, error) {
+	balances := make(map[string]*big.Int, len(a.InitBalancesIotx))
+	for addr, iotx := range a.InitBalancesIotx {
+		if _, ok := a.InitBalanceMap[addr]; ok {
+			return nil, errors.Errorf("init balance of %s is given in both Rau and IOTX", addr)
+		}
+		rau, err := parseIotx("init balance of "+addr, iotx)
+		if err != nil {
+			return nil, err
+		}
+		balances[addr] = rau
+	}
+	return balances, nil
+}
+
+// mergeInitBalancesIotx moves the initial balances in IOTX into InitBalanceMap
+func (a *Account) mergeInitBalancesIotx() error {
+	if len(a.InitBalancesIotx) == 0 {
+		return nil
+	}
+	balances, err := a.initBalancesIotxInRau()
+	if err != nil {
+		return err
+	}
+	if a.InitBalanceMap == nil {
+		a.InitBalanceMap = make(map[string]string, len(balances))
+	}
+	for addr, rau := range balances {
+		a.InitBalanceMap[addr] = rau.String()
+	}
+	a.InitBalancesIotx = make(map[string]string)
+	return nil
+}
+
+// NormalizeAddresses rewrites the addresses of the initial balances into the canonical io1 form, see
+// addrutil.NormalizeAddress. The balances of the addresses which turn out to be the same are summed up.
+func (a *Account) NormalizeAddresses() error {
+	addrStrs := make([]string, 0, len(a.InitBalanceMap))
+	for addrStr := range a.InitBalanceMap {
+		addrStrs = append(addrStrs, addrStr)
+	}
+	sort.Strings(addrStrs)
+	balances := make(map[string]*big.Int, len(addrStrs))
+	for _, addrStr := range addrStrs {
+		canonical, err := addrutil.NormalizeAddress(addrStr)
+		if err != nil {
+			return errors.Wrapf(err, "failed to normalize init balance address %s", addrStr)
+		}
+		amount, err := parseAmount("init balance of "+addrStr, a.InitBalanceMap[addrStr])
+		if err != nil {
+			return err
+		}
+		if balance, ok := balances[canonical]; ok {
+			balance.Add(balance, amount)
+		} else {
+			balances[canonical] = amount
+		}
+	}
+	initBalanceMap := make(map[string]string, len(balances))
+	for addrStr, balance := range balances {
+		initBalanceMap[addrStr] = balance.String()
+	}
+	a.InitBalanceMap = initBalanceMap
+	return nil
+}
+
+// BootstrapDelegates returns the operator addresses of the bootstrap delegates and the corresponding votes, in the
+// order of the operator address
+func (p *Poll) BootstrapDelegates() ([]address.Address, []*big.Int, error) {
+	delegates := make([]Delegate, len(p.Delegates))
+	copy(delegates, p.Delegates)
+	sort.SliceStable(delegates, func(i, j int) bool {
+		return delegates[i].OperatorAddrStr < delegates[j].OperatorAddrStr
+	})
+	addrs := make([]address.Address, 0, len(delegates))
+	votes := make([]*big.Int, 0, len(delegates))
+	for _, d := range delegates {
+		addr, err := address.FromString(d.OperatorAddrStr)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to decode delegate operator address %s", d.OperatorAddrStr)
+		}
+		vote, err := parseAmount("votes of delegate "+d.OperatorAddrStr, d.VotesStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		addrs = append(addrs, addr)
+		votes = append(votes, vote)
+	}
+	return addrs, votes, nil
+}
+
+// TotalDelegateVotes returns the sum of the votes of the bootstrap delegates, which is zero if there is no delegate
+func (p *Poll) TotalDelegateVotes() *big.Int {
+	total := big.NewInt(0)
+	for i := range p.Delegates {
+		total.Add(total, p.Delegates[i].Votes())
+	}
+	return total
+}
+
+// Quorum returns numerator/denominator of the total votes of the bootstrap delegates, rounded down, e.g., Quorum(2, 3)
+// for 2/3 of the votes. It returns zero if the fraction is negative or the denominator is zero.
+func (p *Poll) Quorum(numerator, denominator int) *big.Int {
+	if numerator < 0 || denominator <= 0 {
+		return big.NewInt(0)
+	}
+	quorum := new(big.Int).Mul(p.TotalDelegateVotes(), big.NewInt(int64(numerator)))
+	return quorum.Div(quorum, big.NewInt(int64(denominator)))
 }
 
 // OperatorAddr is the address of operator
@@ -708,6 +1470,77 @@ func (r *Rewarding) ExemptAddrsFromEpochReward() []address.Address {
 	return addrs
 }
 
+// ExemptAddrsSet returns the set of addresses in encoded string format that exempt from epoch reward
+func (r *Rewarding) ExemptAddrsSet() map[string]struct{} {
+	set := make(map[string]struct{}, len(r.ExemptAddrStrsFromEpochReward))
+	for _, addr := range r.ExemptAddrsFromEpochReward() {
+		set[addr.String()] = struct{}{}
+	}
+	return set
+}
+
+// EpochRewardRecipients returns the top NumDelegatesForEpochReward addresses of the ranked candidates in order,
+// skipping the ones exempt from epoch reward
+func (r *Rewarding) EpochRewardRecipients(ranked []address.Address) []address.Address {
+	exempt := r.ExemptAddrsSet()
+	recipients := make([]address.Address, 0, r.NumDelegatesForEpochReward)
+	for _, addr := range ranked {
+		if uint64(len(recipients)) >= r.NumDelegatesForEpochReward {
+			break
+		}
+		if _, ok := exempt[addr.String()]; ok {
+			continue
+		}
+		recipients = append(recipients, addr)
+	}
+	return recipients
+}
+
+// EpochRewardRecipient is a recipient of the epoch reward and its votes
+type EpochRewardRecipient struct {
+	Addr  address.Address
+	Votes *big.Int
+}
+
+// SplitEpochReward splits the epoch reward among the recipients in proportion to their votes, where the reward is
+// AleutianEpochReward if aleutian is true, or EpochReward otherwise. The remainder of rounding down goes to the top
+// recipient, i.e., the one with the most votes, and the one with the smallest address among the ties. The amounts
+// are keyed by the encoded address, and all of them are zero if the recipients have no votes at all.
+func (r *Rewarding) SplitEpochReward(recipients []EpochRewardRecipient, aleutian bool) map[string]*big.Int {
+	total := r.EpochReward()
+	if aleutian {
+		total = r.AleutianEpochReward()
+	}
+	amounts := make(map[string]*big.Int, len(recipients))
+	totalVotes := big.NewInt(0)
+	var top *EpochRewardRecipient
+	for i := range recipients {
+		rcpt := &recipients[i]
+		amounts[rcpt.Addr.String()] = big.NewInt(0)
+		totalVotes.Add(totalVotes, rcpt.Votes)
+		if top == nil {
+			top = rcpt
+			continue
+		}
+		switch c := rcpt.Votes.Cmp(top.Votes); {
+		case c > 0, c == 0 && rcpt.Addr.String() < top.Addr.String():
+			top = rcpt
+		}
+	}
+	if totalVotes.Sign() == 0 {
+		return amounts
+	}
+	remainder := new(big.Int).Set(total)
+	for _, rcpt := range recipients {
+		amount := new(big.Int).Mul(total, rcpt.Votes)
+		amount.Div(amount, totalVotes)
+		amounts[rcpt.Addr.String()].Add(amounts[rcpt.Addr.String()], amount)
+		remainder.Sub(remainder, amount)
+	}
+	amounts[top.Addr.String()].Add(amounts[top.Addr.String()], remainder)
+	return amounts
+}
+
 // FoundationBonus returns the bootstrap bonus amount rewarded per epoch
 func (r *Rewarding) FoundationBonus() *big.Int {
 	val, ok := new(big.Int).SetString(r.FoundationBonusStr, 10)
@@ -716,3 +1549,315 @@ func (r *Rewarding) FoundationBonus() *big.Int {
 	}
 	return val
 }
+
+// BlockRewardIOTX returns the block reward amount in IOTX for display
+func (r *Rewarding) BlockRewardIOTX() *big.Float {
+	return unit.ConvertRauToIotx(r.BlockReward())
+}
+
+// DardanellesBlockRewardIOTX returns the block reward amount after dardanelles fork in IOTX for display
+func (r *Rewarding) DardanellesBlockRewardIOTX() *big.Float {
+	return unit.ConvertRauToIotx(r.DardanellesBlockReward())
+}
+
+// EpochRewardIOTX returns the epoch reward amount in IOTX for display
+func (r *Rewarding) EpochRewardIOTX() *big.Float {
+	return unit.ConvertRauToIotx(r.EpochReward())
+}
+
+// AleutianEpochRewardIOTX returns the epoch reward amount after Aleutian fork in IOTX for display
+func (r *Rewarding) AleutianEpochRewardIOTX() *big.Float {
+	return unit.ConvertRauToIotx(r.AleutianEpochReward())
+}
+
+// FoundationBonusIOTX returns the bootstrap bonus amount rewarded per epoch in IOTX for display
+func (r *Rewarding) FoundationBonusIOTX() *big.Float {
+	return unit.ConvertRauToIotx(r.FoundationBonus())
+}
+
+// IsFoundationBonusEpoch checks whether the foundation bonus is granted in the epoch, which is either in phase 1 up to
+// the last epoch, or in the phase 2 window
+func (r *Rewarding) IsFoundationBonusEpoch(epoch uint64) bool {
+	if epoch <= r.FoundationBonusLastEpoch {
+		return true
+	}
+	return epoch >= r.FoundationBonusP2StartEpoch && epoch <= r.FoundationBonusP2EndEpoch
+}
+
+// FoundationBonusAt returns the foundation bonus granted in the epoch, which is zero if the epoch is not eligible
+func (r *Rewarding) FoundationBonusAt(epoch uint64) *big.Int {
+	if !r.IsFoundationBonusEpoch(epoch) {
+		return big.NewInt(0)
+	}
+	return r.FoundationBonus()
+}
+
+// FundAddress returns the address of the rewarding fund, i.e., the rewarding protocol address, which is derived from
+// the hash160 of the rewarding protocol ID "rewarding"
+func (r *Rewarding) FundAddress() address.Address {
+	addr, err := address.FromBytes(address.RewardingProtocolAddrHash[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the rewarding fund address", zap.Error(err))
+	}
+	return addr
+}
+
+// Validate validates the rewarding config
+func (r *Rewarding) Validate() error {
+	for _, v := range []struct {
+		name, amount string
+	}{
+		{"init balance", r.InitBalanceStr},
+		{"block reward", r.BlockRewardStr},
+		{"dardanelles block reward", r.DardanellesBlockRewardStr},
+		{"epoch reward", r.EpochRewardStr},
+		{"aleutian epoch reward", r.AleutianEpochRewardStr},
+		{"foundation bonus", r.FoundationBonusStr},
+	} {
+		if _, err := parseAmount(v.name, v.amount); err != nil {
+			return err
+		}
+	}
+	if r.NumDelegatesForEpochReward == 0 {
+		return errors.New("number of delegates for epoch reward is zero")
+	}
+	if r.FoundationBonusP2StartEpoch > 0 || r.FoundationBonusP2EndEpoch > 0 {
+		if r.FoundationBonusP2StartEpoch > r.FoundationBonusP2EndEpoch {
+			return errors.Errorf(
+				"foundation bonus p2 start epoch %d is larger than end epoch %d",
+				r.FoundationBonusP2StartEpoch,
+				r.FoundationBonusP2EndEpoch,
+			)
+		}
+		if r.FoundationBonusP2StartEpoch <= r.FoundationBonusLastEpoch {
+			return errors.Errorf(
+				"foundation bonus p2 start epoch %d overlaps with last epoch %d",
+				r.FoundationBonusP2StartEpoch,
+				r.FoundationBonusLastEpoch,
+			)
+		}
+	}
+	return nil
+}
+
+// ProbationWindow returns the inclusive range of epochs whose unproductive delegates are still in the probation list of
+// currentEpoch, i.e., the ProbationEpochPeriod epochs before it. (0, 0) is returned if no epoch is tracked.
+func (p *Poll) ProbationWindow(currentEpoch uint64) (from, to uint64) {
+	if p.ProbationEpochPeriod == 0 || currentEpoch <= 1 {
+		return 0, 0
+	}
+	from = 1
+	if currentEpoch > p.ProbationEpochPeriod {
+		from = currentEpoch - p.ProbationEpochPeriod
+	}
+	return from, currentEpoch - 1
+}
+
+// ProbationIntensity returns the percentage of the votes a delegate loses during probation, where 100 means the
+// delegate is not eligible for block production at all. A rate larger than 100 is rejected by Validate.
+func (p *Poll) ProbationIntensity() uint32 {
+	return p.ProbationIntensityRate
+}
+
+// SystemSGDAddr returns the address of the system sgd contract, which is nil if the contract is not configured
+func (p *Poll) SystemSGDAddr() address.Address {
+	if p.SystemSGDContractAddress == "" {
+		return nil
+	}
+	addr, err := address.FromString(p.SystemSGDContractAddress)
+	if err != nil {
+		log.L().Panic("Error when decoding the system sgd contract address from string.", zap.Error(err))
+	}
+	return addr
+}
+
+// SystemSGDActive checks whether the system sgd contract is configured and deployed at height
+func (p *Poll) SystemSGDActive(height uint64) bool {
+	return p.SystemSGDContractAddress != "" && height >= p.SystemSGDContractHeight
+}
+
+// Validate checks that the operator and reward addresses of the delegates could be decoded, and their votes are
+// non-negative decimals, so that the accessors of Delegate don't panic, that the unproductive delegate cache could
+// hold the whole probation period, and that the probation intensity rate is within [0, 100]. If the delegates are
+// pulled from the gravity chain, i.e., the gravity chain voting is enabled with a non-zero start height, the pull
+// interval must be positive, the start height must be below the ceiling height, where a zero ceiling height means no
+// ceiling, and the register and staking contracts must be set, otherwise the poll results would be empty. Note that
+// these heights are gravity chain heights rather than IoTeX heights. The system contracts, including the system staking and
+// sgd contracts, must have unique names and decodable addresses, and the code if given must be hex-encoded.
+func (p *Poll) Validate() error {
+	if p.EnableGravityChainVoting && p.GravityChainStartHeight != 0 {
+		if p.GravityChainHeightInterval == 0 {
+			return errors.New("gravity chain height interval is zero")
+		}
+		if p.GravityChainCeilingHeight != 0 && p.GravityChainStartHeight >= p.GravityChainCeilingHeight {
+			return errors.Errorf(
+				"gravity chain start height %d is not below ceiling height %d",
+				p.GravityChainStartHeight,
+				p.GravityChainCeilingHeight,
+			)
+		}
+		if p.RegisterContractAddress == "" {
+			return errors.New("gravity chain voting is enabled without the register contract address")
+		}
+		if p.StakingContractAddress == "" {
+			return errors.New("gravity chain voting is enabled without the staking contract address")
+		}
+	}
+	if p.UnproductiveDelegateMaxCacheSize < p.ProbationEpochPeriod {
+		return errors.Errorf(
+			"unproductive delegate max cache size %d is smaller than probation epoch period %d",
+			p.UnproductiveDelegateMaxCacheSize,
+			p.ProbationEpochPeriod,
+		)
+	}
+	if p.ProbationIntensityRate > 100 {
+		return errors.Errorf("probation intensity rate %d is larger than 100", p.ProbationIntensityRate)
+	}
+	if p.SystemSGDContractAddress != "" {
+		if _, err := address.FromString(p.SystemSGDContractAddress); err != nil {
+			return errors.Wrapf(err, "invalid system sgd contract address %s", p.SystemSGDContractAddress)
+		}
+	}
+	names := make(map[string]struct{}, len(p.SystemContracts)+2)
+	for _, c := range p.allSystemContracts() {
+		if c.Name == "" {
+			return errors.Errorf("system contract %s has no name", c.Address)
+		}
+		if _, ok := names[c.Name]; ok {
+			return errors.Errorf("duplicate system contract %s", c.Name)
+		}
+		names[c.Name] = struct{}{}
+		if _, err := address.FromString(c.Address); err != nil {
+			return errors.Wrapf(err, "invalid address %s of system contract %s", c.Address, c.Name)
+		}
+		if c.Code != "" {
+			if _, err := hex.DecodeString(strings.TrimPrefix(c.Code, "0x")); err != nil {
+				return errors.Wrapf(err, "invalid code of system contract %s", c.Name)
+			}
+		}
+	}
+	for i, d := range p.Delegates {
+		if _, err := address.FromString(d.OperatorAddrStr); err != nil {
+			return errors.Wrapf(err, "invalid operator address %s of delegate %d", d.OperatorAddrStr, i)
+		}
+		if d.RewardAddrStr != "" {
+			if _, err := address.FromString(d.RewardAddrStr); err != nil {
+				return errors.Wrapf(err, "invalid reward address %s of delegate %d", d.RewardAddrStr, i)
+			}
+		}
+		if _, err := parseAmount(fmt.Sprintf("votes of delegate %d", i), d.VotesStr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MinSelfStakeAmount returns the minimum self-stake amount for a candidate to be eligible
+func (s *Staking) MinSelfStakeAmount() *big.Int {
+	val, ok := new(big.Int).SetString(s.RegistrationConsts.MinSelfStake, 10)
+	if !ok {
+		log.S().Panicf("Error when casting min self-stake string %s into big int", s.RegistrationConsts.MinSelfStake)
+	}
+	return val
+}
+
+// Validate checks that the vote weight constants make sense, the withdraw waiting period is positive, the staking
+// amounts are non-negative with positive minimum stake amounts, and the bootstrap candidates are well-formed
+func (s *Staking) Validate() error {
+	if s.VoteWeightCalConsts.DurationLg <= 0 {
+		return errors.Errorf("duration lg %v is not positive", s.VoteWeightCalConsts.DurationLg)
+	}
+	if s.VoteWeightCalConsts.AutoStake < 1 {
+		return errors.Errorf("auto stake multiplier %v is less than 1", s.VoteWeightCalConsts.AutoStake)
+	}
+	if s.VoteWeightCalConsts.SelfStake < 1 {
+		return errors.Errorf("self stake multiplier %v is less than 1", s.VoteWeightCalConsts.SelfStake)
+	}
+	if s.WithdrawWaitingPeriod <= 0 {
+		return errors.Errorf("withdraw waiting period %s is not positive", s.WithdrawWaitingPeriod)
+	}
+	if _, err := parseAmount("registration fee", s.RegistrationConsts.Fee); err != nil {
+		return err
+	}
+	for _, v := range []struct {
+		name, amount string
+	}{
+		{"min self-stake", s.RegistrationConsts.MinSelfStake},
+		{"min stake amount", s.MinStakeAmount},
+	} {
+		amount, err := parseAmount(v.name, v.amount)
+		if err != nil {
+			return err
+		}
+		if amount.Sign() == 0 {
+			return errors.Errorf("%s is zero", v.name)
+		}
+	}
+	names := make(map[string]struct{}, len(s.BootstrapCandidates))
+	for i, c := range s.BootstrapCandidates {
+		if err := c.validate(); err != nil {
+			return errors.Wrapf(err, "invalid bootstrap candidate %d", i)
+		}
+		if _, ok := names[c.Name]; ok {
+			return errors.Errorf("duplicate name %s of bootstrap candidate %d", c.Name, i)
+		}
+		names[c.Name] = struct{}{}
+	}
+	return nil
+}
+
+func (c *BootstrapCandidate) validate() error {
+	for _, v := range []struct {
+		name, addr string
+	}{
+		{"owner", c.OwnerAddress},
+		{"operator", c.OperatorAddress},
+		{"reward", c.RewardAddress},
+	} {
+		if _, err := address.FromString(v.addr); err != nil {
+			return errors.Wrapf(err, "invalid %s address %s", v.name, v.addr)
+		}
+	}
+	if c.Name == "" {
+		return errors.New("name is empty")
+	}
+	_, err := parseAmount("self-staking tokens", c.SelfStakingTokens)
+	return err
+}
+
+// WithdrawWaitingPeriodNanos returns the withdraw waiting period in nanoseconds
+func (s *Staking) WithdrawWaitingPeriodNanos() int64 {
+	return s.WithdrawWaitingPeriod.Nanoseconds()
+}
+
+// parseIotx parses the decimal amount in IOTX of the monetary field name into Rau. The amount must be non-negative with
+// at most 18 decimals, since 1 IOTX is 10^18 Rau.
+func parseIotx(name, amount string) (*big.Int, error) {
+	intPart, fracPart, _ := strings.Cut(amount, ".")
+	if intPart == "" || strings.ContainsAny(fracPart, "+-") {
+		return nil, errors.Errorf("invalid %s: %s", name, amount)
+	}
+	if len(fracPart) > 18 {
+		return nil, errors.Errorf("%s has more than 18 decimals: %s", name, amount)
+	}
+	val, ok := new(big.Int).SetString(intPart+fracPart+strings.Repeat("0", 18-len(fracPart)), 10)
+	if !ok {
+		return nil, errors.Errorf("invalid %s: %s", name, amount)
+	}
+	if val.Sign() < 0 {
+		return nil, errors.Errorf("%s is negative: %s", name, amount)
+	}
+	return val, nil
+}
+
+// parseAmount parses the decimal amount of the monetary field name, which must be non-negative
+func parseAmount(name, amount string) (*big.Int, error) {
+	val, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid %s: %s", name, amount)
+	}
+	if val.Sign() < 0 {
+		return nil, errors.Errorf("%s is negative: %s", name, amount)
+	}
+	return val, nil
+}
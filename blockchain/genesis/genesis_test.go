@@ -7,10 +7,26 @@ package genesis
 
 import (
 	"encoding/hex"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-address/address/bech32"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -33,16 +49,1165 @@ func TestHash(t *testing.T) {
 	require.NoError(err)
 	hash := cfg.Hash()
 	require.Equal("3dfcdee76186b59a9f9abd0ded8e6c093c35bddea23834044550fb68626adb62", hex.EncodeToString(hash[:]))
+	require.Equal("3dfcdee76186b59a9f9abd0ded8e6c093c35bddea23834044550fb68626adb62", cfg.HashHex())
+
+	// the hash follows the changes of the config
+	cfg.NumDelegates++
+	hash = cfg.Hash()
+	require.Equal(hex.EncodeToString(hash[:]), cfg.HashHex())
+	require.NotEqual("3dfcdee76186b59a9f9abd0ded8e6c093c35bddea23834044550fb68626adb62", cfg.HashHex())
+}
+
+func TestGenesis_ValidateTotalSupply(t *testing.T) {
+	require := require.New(t)
+	g := Genesis{}
+	g.InitBalanceMap = map[string]string{
+		identityset.Address(1).String(): "100",
+		identityset.Address(2).String(): "200",
+	}
+	g.InitBalancesIotx = map[string]string{identityset.Address(3).String(): "0.000000000000000003"}
+	g.InitBalanceStr = "1000"
+	require.NoError(g.ValidateTotalSupply(big.NewInt(1303)))
+	require.NoError(g.ValidateTotalSupply(big.NewInt(2000)))
+	require.ErrorContains(g.ValidateTotalSupply(big.NewInt(1302)), "initial total supply 1303 exceeds cap 1302")
+	require.ErrorContains(g.ValidateTotalSupply(nil), "invalid total supply cap")
+	require.ErrorContains(g.ValidateTotalSupply(big.NewInt(-1)), "invalid total supply cap")
+
+	g.InitBalanceStr = "-1"
+	require.ErrorContains(g.ValidateTotalSupply(big.NewInt(2000)), "rewarding init balance is negative")
+	g.InitBalanceStr = "1000"
+	g.InitBalanceMap[identityset.Address(1).String()] = "1e2"
+	require.ErrorContains(g.ValidateTotalSupply(big.NewInt(2000)), "invalid init balance")
+
+	// the default genesis of mainnet is within the total supply of 10 billion IOTX
+	g = GetDefault()
+	supply := new(big.Int).Mul(big.NewInt(10_000_000_000), big.NewInt(unit.Iotx))
+	require.NoError(g.ValidateTotalSupply(supply))
+}
+
+func TestGenesis_Proto(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	g.NumDelegates = 7
+	g.GravityChainStartHeight = 7614500
+	g.Delegates = []Delegate{{OperatorAddrStr: identityset.Address(1).String(), VotesStr: "100"}}
+	g.FoundationBonusLastEpoch = 100
+	p := g.Proto()
+	require.Len(p.GetAccount().GetInitBalanceAddrs(), len(g.InitBalanceMap))
+
+	g2, err := FromProto(p)
+	require.NoError(err)
+	require.Equal(g.Hash(), g2.Hash())
+	require.True(proto.Equal(p, g2.Proto()))
+	require.Equal(g.InitBalanceMap, g2.InitBalanceMap)
+	require.Equal(g.Delegates, g2.Delegates)
+	require.Equal(g.BlockInterval, g2.BlockInterval)
+	// the fields not covered by the proto take the default values
+	require.Equal(GetDefault().SumatraBlockHeight, g2.SumatraBlockHeight)
+
+	// the network identity is not covered by the proto, but carried along with it by IdentifiedProto
+	g.ChainID, g.ChainName, g.HashVersion = 2, "testnet", 1
+	g2, err = FromProto(g.Proto())
+	require.NoError(err)
+	require.NotEqual(g.Hash(), g2.Hash())
+	g2, err = FromIdentifiedProto(g.IdentifiedProto())
+	require.NoError(err)
+	require.Equal(g.Hash(), g2.Hash())
+	require.EqualValues(2, g2.ChainID)
+	require.Equal("testnet", g2.ChainName)
+	require.EqualValues(1, g2.HashVersion)
+	_, err = FromIdentifiedProto(&IdentifiedProto{Genesis: g.Proto()})
+	require.ErrorContains(err, "invalid network identity")
+
+	for _, v := range []struct {
+		modify func(*iotextypes.Genesis)
+		errMsg string
+	}{
+		{func(p *iotextypes.Genesis) { p.Poll = nil }, "genesis proto is incomplete"},
+		{func(p *iotextypes.Genesis) { p.Account.InitBalances = p.Account.InitBalances[1:] }, "init balance addresses mismatch"},
+		{func(p *iotextypes.Genesis) { p.Account.InitBalanceAddrs[1] = p.Account.InitBalanceAddrs[0] }, "duplicate init balance"},
+	} {
+		p := g.Proto()
+		v.modify(p)
+		_, err := FromProto(p)
+		require.ErrorContains(err, v.errMsg)
+	}
 }
 func TestAccount_InitBalances(t *testing.T) {
 	require := require.New(t)
 	InitBalanceMap := make(map[string]string, 0)
 	InitBalanceMap["io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6"] = "1"
 	InitBalanceMap["io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms"] = "2"
-	acc := Account{InitBalanceMap}
+	acc := Account{InitBalanceMap: InitBalanceMap}
 	adds, balances := acc.InitBalances()
 	require.Equal("io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6", adds[0].String())
 	require.Equal("io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms", adds[1].String())
 	require.Equal(InitBalanceMap["io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6"], balances[0].Text(10))
 	require.Equal(InitBalanceMap["io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms"], balances[1].Text(10))
 }
+
+func TestBlockchain_NetworkName(t *testing.T) {
+	require := require.New(t)
+	g, err := New("")
+	require.NoError(err)
+	require.Equal("mainnet", g.NetworkName())
+	require.EqualValues(1, g.ChainID)
+	h := g.Hash()
+
+	for _, v := range []struct {
+		name     string
+		id       uint32
+		expected string
+	}{
+		{"", 1, "mainnet"},
+		{"", 2, "testnet"},
+		{"", 3, "unknown"},
+		{"devnet", 3, "devnet"},
+		{"", 1337, "devnet"},
+	} {
+		g.ChainName, g.ChainID = v.name, v.id
+		require.Equal(v.expected, g.NetworkName())
+		// the network identity is not hashed before hash version 1
+		g.HashVersion = 0
+		require.Equal(h, g.Hash())
+		g.HashVersion = 1
+		require.NotEqual(h, g.Hash())
+	}
+}
+
+func TestBlockchain_IsValidChainID(t *testing.T) {
+	require := require.New(t)
+	g := GetDefault()
+	for _, v := range []struct {
+		height   uint64
+		id       uint32
+		expected bool
+	}{
+		{g.MidwayBlockHeight - 1, 1, true},
+		{g.MidwayBlockHeight - 1, 0, true},
+		{g.MidwayBlockHeight - 1, 2, true},
+		{g.MidwayBlockHeight, 1, true},
+		{g.MidwayBlockHeight, 0, true},
+		{g.MidwayBlockHeight, 2, false},
+		{g.QuebecBlockHeight - 1, 0, true},
+		{g.QuebecBlockHeight, 1, true},
+		{g.QuebecBlockHeight, 0, false},
+		{g.QuebecBlockHeight, 2, false},
+	} {
+		require.Equal(v.expected, g.IsValidChainID(v.id, v.height))
+	}
+}
+
+func TestBlockchain_GasLimitAt(t *testing.T) {
+	require := require.New(t)
+	g := Default
+	for _, height := range []uint64{0, 1, g.SumatraBlockHeight, math.MaxUint64} {
+		require.Equal(g.BlockGasLimit, g.BlockGasLimitAt(height))
+		require.Equal(g.ActionGasLimit, g.ActionGasLimitAt(height))
+	}
+}
+
+func TestBlockchain_EVMRulesAt(t *testing.T) {
+	require := require.New(t)
+	g := GetDefault()
+	for _, v := range []struct {
+		height   uint64
+		expected EVMRules
+	}{
+		{0, EVMRules{}},
+		{g.IcelandBlockHeight - 1, EVMRules{}},
+		{g.IcelandBlockHeight, EVMRules{IstanbulEnabled: true, ChainIDOpcode: true}},
+		{g.OkhotskBlockHeight, EVMRules{IstanbulEnabled: true, BerlinEnabled: true, LondonEnabled: true, ChainIDOpcode: true}},
+		{g.SumatraBlockHeight - 1, EVMRules{IstanbulEnabled: true, BerlinEnabled: true, LondonEnabled: true, ChainIDOpcode: true}},
+		{g.SumatraBlockHeight, EVMRules{true, true, true, true, true}},
+	} {
+		require.Equal(v.expected, g.EVMRulesAt(v.height))
+	}
+}
+
+func TestBlockchain_CheckGas(t *testing.T) {
+	require := require.New(t)
+	g := Default
+	require.NoError(g.CheckActionGas(g.ActionGasLimit))
+	require.Error(g.CheckActionGas(g.ActionGasLimit + 1))
+	require.NoError(g.CheckBlockGas(g.BlockGasLimit))
+	require.Error(g.CheckBlockGas(g.BlockGasLimit + 1))
+}
+
+func TestBlockchain_MinGasPrice(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	require.Equal(big.NewInt(unit.Qev), g.MinGasPrice())
+	require.Equal(big.NewInt(unit.Qev), (&Blockchain{}).MinGasPrice())
+
+	g.MinGasPriceStr = "0"
+	require.NoError(g.Validate())
+	require.Zero(g.MinGasPrice().Sign())
+	for _, v := range []struct {
+		price, errMsg string
+	}{
+		{"1.5", "invalid min gas price: 1.5"},
+		{"-1", "min gas price is negative: -1"},
+	} {
+		g.MinGasPriceStr = v.price
+		require.ErrorContains(g.Validate(), v.errMsg)
+	}
+	g.MinGasPriceStr = "1.5"
+	require.Panics(func() { g.MinGasPrice() })
+}
+
+func TestDurations(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	require.Equal(int64(10*time.Second), g.BlockIntervalNanos())
+	require.Equal(int64(72*time.Hour), g.WithdrawWaitingPeriodNanos())
+	require.NoError(g.Validate())
+
+	for _, v := range []struct {
+		blockInterval, withdrawWaitingPeriod time.Duration
+		success                              bool
+	}{
+		{time.Second, time.Hour, true},
+		{time.Second, 0, false},
+		{0, time.Hour, false},
+		{-time.Second, time.Hour, false},
+		{time.Second, -time.Hour, false},
+	} {
+		g.BlockInterval, g.WithdrawWaitingPeriod = v.blockInterval, v.withdrawWaitingPeriod
+		if v.success {
+			require.NoError(g.Validate())
+		} else {
+			require.Error(g.Validate())
+		}
+	}
+}
+
+func TestHeightToTime(t *testing.T) {
+	require := require.New(t)
+	g := Default.Blockchain
+	genesisTime := time.Date(2019, 1, 1, 8, 0, 0, 0, time.UTC)
+	require.Equal(genesisTime, g.GenesisTime())
+	require.Equal(time.UTC, g.GenesisTime().Location())
+
+	d := g.DardanellesBlockHeight
+	for _, v := range []struct {
+		height   uint64
+		expected time.Time
+	}{
+		{0, genesisTime},
+		{1, genesisTime.Add(10 * time.Second)},
+		{d - 1, genesisTime.Add(time.Duration(d-1) * 10 * time.Second)},
+		{d, genesisTime.Add(time.Duration(d-1)*10*time.Second + 5*time.Second)},
+		{d + 10, genesisTime.Add(time.Duration(d-1)*10*time.Second + 55*time.Second)},
+	} {
+		require.Equal(v.expected, g.HeightToTime(v.height), v.height)
+	}
+
+	// dardanelles is activated since genesis
+	g.DardanellesBlockHeight = 0
+	require.Equal(genesisTime.Add(50*time.Second), g.HeightToTime(10))
+	g.DardanellesBlockHeight = 1
+	require.Equal(genesisTime.Add(50*time.Second), g.HeightToTime(10))
+}
+
+func TestRewarding_FoundationBonusAt(t *testing.T) {
+	require := require.New(t)
+	r := Default.Rewarding
+	bonus := r.FoundationBonus()
+	zero := big.NewInt(0)
+	for _, v := range []struct {
+		epoch    uint64
+		eligible bool
+	}{
+		{0, true},
+		{r.FoundationBonusLastEpoch, true},
+		{r.FoundationBonusLastEpoch + 1, false},
+		{r.FoundationBonusP2StartEpoch - 1, false},
+		{r.FoundationBonusP2StartEpoch, true},
+		{r.FoundationBonusP2EndEpoch, true},
+		{r.FoundationBonusP2EndEpoch + 1, false},
+	} {
+		require.Equal(v.eligible, r.IsFoundationBonusEpoch(v.epoch), v.epoch)
+		if v.eligible {
+			require.Equal(bonus, r.FoundationBonusAt(v.epoch))
+		} else {
+			require.Equal(zero, r.FoundationBonusAt(v.epoch))
+		}
+	}
+
+	// p2 is disabled
+	r.FoundationBonusP2StartEpoch, r.FoundationBonusP2EndEpoch = 0, 0
+	require.True(r.IsFoundationBonusEpoch(r.FoundationBonusLastEpoch))
+	require.False(r.IsFoundationBonusEpoch(r.FoundationBonusLastEpoch + 1))
+}
+
+func TestRewarding_IOTX(t *testing.T) {
+	require := require.New(t)
+	r := GetDefault().Rewarding
+	for _, v := range []struct {
+		amount *big.Float
+		expect string
+	}{
+		{r.BlockRewardIOTX(), "16"},
+		{r.DardanellesBlockRewardIOTX(), "8"},
+		{r.EpochRewardIOTX(), "12500"},
+		{r.AleutianEpochRewardIOTX(), "18750"},
+		{r.FoundationBonusIOTX(), "80"},
+	} {
+		require.Equal(v.expect, v.amount.Text('f', -1))
+	}
+
+	// the smallest fraction is kept
+	r.BlockRewardStr = "1000000000000000001"
+	require.Equal("1.000000000000000001", r.BlockRewardIOTX().Text('f', 18))
+	r.BlockRewardStr = "0"
+	require.Zero(r.BlockRewardIOTX().Sign())
+}
+
+func TestRewarding_EpochRewardRecipients(t *testing.T) {
+	require := require.New(t)
+	r := Rewarding{
+		NumDelegatesForEpochReward:    3,
+		ExemptAddrStrsFromEpochReward: []string{identityset.Address(1).String(), identityset.Address(9).String()},
+	}
+	require.Len(r.ExemptAddrsSet(), 2)
+
+	ranked := make([]address.Address, 0)
+	for i := 0; i < 6; i++ {
+		ranked = append(ranked, identityset.Address(i))
+	}
+	// address 1 is skipped in the top 3 rather than truncated
+	require.Equal([]address.Address{
+		identityset.Address(0),
+		identityset.Address(2),
+		identityset.Address(3),
+	}, r.EpochRewardRecipients(ranked))
+	require.Equal([]address.Address{identityset.Address(0)}, r.EpochRewardRecipients(ranked[:2]))
+	require.Empty(r.EpochRewardRecipients(nil))
+}
+
+func TestRewarding_SplitEpochReward(t *testing.T) {
+	require := require.New(t)
+	r := Rewarding{EpochRewardStr: "100", AleutianEpochRewardStr: "1000"}
+	require.Empty(r.SplitEpochReward(nil, false))
+
+	addr := func(i int) address.Address { return identityset.Address(i) }
+	sum := func(amounts map[string]*big.Int) *big.Int {
+		total := big.NewInt(0)
+		for _, amount := range amounts {
+			total.Add(total, amount)
+		}
+		return total
+	}
+	recipients := []EpochRewardRecipient{
+		{addr(1), big.NewInt(1)},
+		{addr(2), big.NewInt(1)},
+		{addr(3), big.NewInt(1)},
+	}
+	// the remainder 1 goes to the smallest address among the ties
+	top := addr(1).String()
+	for _, a := range []address.Address{addr(2), addr(3)} {
+		if a.String() < top {
+			top = a.String()
+		}
+	}
+	amounts := r.SplitEpochReward(recipients, false)
+	require.Len(amounts, 3)
+	require.Equal(big.NewInt(100), sum(amounts))
+	for a, amount := range amounts {
+		if a == top {
+			require.Equal(big.NewInt(34), amount)
+		} else {
+			require.Equal(big.NewInt(33), amount)
+		}
+	}
+
+	// the remainder goes to the one with the most votes
+	recipients[1].Votes = big.NewInt(4)
+	amounts = r.SplitEpochReward(recipients, true)
+	require.Equal(big.NewInt(1000), sum(amounts))
+	require.Equal(big.NewInt(166), amounts[addr(1).String()])
+	require.Equal(big.NewInt(668), amounts[addr(2).String()])
+	require.Equal(big.NewInt(166), amounts[addr(3).String()])
+
+	// no votes at all
+	for i := range recipients {
+		recipients[i].Votes = big.NewInt(0)
+	}
+	amounts = r.SplitEpochReward(recipients, false)
+	require.Len(amounts, 3)
+	require.Zero(sum(amounts).Sign())
+}
+
+func TestRewarding_FundAddress(t *testing.T) {
+	require := require.New(t)
+	h := hash.Hash160b([]byte("rewarding"))
+	expected, err := address.FromBytes(h[:])
+	require.NoError(err)
+	require.Equal(expected, Default.FundAddress())
+	require.Equal(address.RewardingProtocol, Default.FundAddress().String())
+}
+
+func TestRewarding_Validate(t *testing.T) {
+	require := require.New(t)
+	require.NoError(Default.Rewarding.Validate())
+
+	for _, v := range []struct {
+		name   string
+		modify func(*Rewarding)
+	}{
+		{"swapped p2 window", func(r *Rewarding) {
+			r.FoundationBonusP2StartEpoch, r.FoundationBonusP2EndEpoch = r.FoundationBonusP2EndEpoch, r.FoundationBonusP2StartEpoch
+		}},
+		{"p2 overlapping last epoch", func(r *Rewarding) {
+			r.FoundationBonusP2StartEpoch = r.FoundationBonusLastEpoch
+		}},
+		{"zero delegates for epoch reward", func(r *Rewarding) {
+			r.NumDelegatesForEpochReward = 0
+		}},
+		{"negative block reward", func(r *Rewarding) {
+			r.BlockRewardStr = "-1"
+		}},
+		{"invalid foundation bonus", func(r *Rewarding) {
+			r.FoundationBonusStr = "1e18"
+		}},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			g := TestDefault()
+			v.modify(&g.Rewarding)
+			require.Error(g.Rewarding.Validate())
+			require.Error(g.Validate())
+		})
+	}
+
+	// p2 is disabled
+	r := Default.Rewarding
+	r.FoundationBonusP2StartEpoch, r.FoundationBonusP2EndEpoch = 0, 0
+	require.NoError(r.Validate())
+}
+
+func TestPoll_Validate(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	require.NoError(g.Poll.Validate())
+
+	for _, v := range []struct {
+		name   string
+		modify func(*Delegate)
+		errMsg string
+	}{
+		{"invalid operator address", func(d *Delegate) {
+			d.OperatorAddrStr = "io1invalid"
+		}, "invalid operator address io1invalid of delegate 2"},
+		{"empty operator address", func(d *Delegate) {
+			d.OperatorAddrStr = ""
+		}, "invalid operator address  of delegate 2"},
+		{"invalid reward address", func(d *Delegate) {
+			d.RewardAddrStr = "io1invalid"
+		}, "invalid reward address io1invalid of delegate 2"},
+		{"invalid votes", func(d *Delegate) {
+			d.VotesStr = "1.5"
+		}, "invalid votes of delegate 2: 1.5"},
+		{"negative votes", func(d *Delegate) {
+			d.VotesStr = "-1"
+		}, "votes of delegate 2 is negative: -1"},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			g := TestDefault()
+			v.modify(&g.Delegates[2])
+			require.ErrorContains(g.Poll.Validate(), v.errMsg)
+			require.ErrorContains(g.Validate(), v.errMsg)
+		})
+	}
+
+	// reward address is optional
+	g = TestDefault()
+	g.Delegates[2].RewardAddrStr = ""
+	require.NoError(g.Poll.Validate())
+
+	g = TestDefault()
+	g.UnproductiveDelegateMaxCacheSize = g.ProbationEpochPeriod
+	require.NoError(g.Poll.Validate())
+	g.UnproductiveDelegateMaxCacheSize--
+	require.ErrorContains(g.Poll.Validate(), "unproductive delegate max cache size 5 is smaller than probation epoch period 6")
+
+	g = TestDefault()
+	g.ProbationIntensityRate = 100
+	require.NoError(g.Poll.Validate())
+	require.EqualValues(100, g.ProbationIntensity())
+	g.ProbationIntensityRate = 101
+	require.ErrorContains(g.Poll.Validate(), "probation intensity rate 101 is larger than 100")
+	require.ErrorContains(g.Validate(), "probation intensity rate 101 is larger than 100")
+
+	// gravity chain heights
+	for _, v := range []struct {
+		enabled                  bool
+		start, ceiling, interval uint64
+		errMsg                   string
+	}{
+		{true, 7614500, 10199000, 3600, ""},
+		{true, 7614500, 0, 3600, ""},
+		{true, 0, 10199000, 0, ""},
+		{false, 7614500, 10199000, 0, ""},
+		{false, 10199000, 7614500, 3600, ""},
+		{true, 7614500, 10199000, 0, "gravity chain height interval is zero"},
+		{true, 10199000, 10199000, 3600, "gravity chain start height 10199000 is not below ceiling height 10199000"},
+		{true, 10199001, 10199000, 3600, "gravity chain start height 10199001 is not below ceiling height 10199000"},
+	} {
+		g = TestDefault()
+		g.EnableGravityChainVoting = v.enabled
+		g.GravityChainStartHeight = v.start
+		g.GravityChainCeilingHeight = v.ceiling
+		g.GravityChainHeightInterval = v.interval
+		g.RegisterContractAddress = "0x95724986563028deb58f15c5fac19fa09304f32d"
+		g.StakingContractAddress = "0x87c9dbff0016af23f5b1ab9b8e072124ab729193"
+		if v.errMsg == "" {
+			require.NoError(g.Poll.Validate())
+		} else {
+			require.ErrorContains(g.Poll.Validate(), v.errMsg)
+		}
+	}
+
+	// gravity chain contracts
+	g = TestDefault()
+	g.EnableGravityChainVoting = true
+	g.GravityChainStartHeight = 7614500
+	g.GravityChainHeightInterval = 3600
+	g.StakingContractAddress = "0x87c9dbff0016af23f5b1ab9b8e072124ab729193"
+	require.ErrorContains(g.Poll.Validate(), "gravity chain voting is enabled without the register contract address")
+	g.RegisterContractAddress = "0x95724986563028deb58f15c5fac19fa09304f32d"
+	g.StakingContractAddress = ""
+	require.ErrorContains(g.Poll.Validate(), "gravity chain voting is enabled without the staking contract address")
+	g.StakingContractAddress = "0x87c9dbff0016af23f5b1ab9b8e072124ab729193"
+	require.NoError(g.Poll.Validate())
+	g.GravityChainStartHeight = 0
+	g.RegisterContractAddress = ""
+	g.StakingContractAddress = ""
+	require.NoError(g.Poll.Validate())
+
+	g = TestDefault()
+	g.SystemSGDContractAddress = "io1invalid"
+	require.ErrorContains(g.Poll.Validate(), "invalid system sgd contract address io1invalid")
+
+	// system contracts
+	for _, v := range []struct {
+		contract SystemContract
+		errMsg   string
+	}{
+		{SystemContract{Name: "bridge", Address: identityset.Address(10).String(), Code: "0x6080"}, ""},
+		{SystemContract{Name: "bridge", Address: identityset.Address(10).String()}, ""},
+		{SystemContract{Address: identityset.Address(10).String()}, "has no name"},
+		{SystemContract{Name: "bridge", Address: "io1invalid"}, "invalid address io1invalid of system contract bridge"},
+		{SystemContract{Name: "bridge", Address: identityset.Address(10).String(), Code: "0x608"}, "invalid code of system contract bridge"},
+		{SystemContract{Name: SystemStakingContractName, Address: identityset.Address(10).String()}, "duplicate system contract systemStaking"},
+	} {
+		g = TestDefault()
+		g.SystemStakingContractAddress = identityset.Address(11).String()
+		g.SystemContracts = []SystemContract{v.contract}
+		if v.errMsg == "" {
+			require.NoError(g.Poll.Validate())
+		} else {
+			require.ErrorContains(g.Poll.Validate(), v.errMsg)
+		}
+	}
+}
+
+func TestGenesis_SystemContract(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	g.SystemStakingContractAddress, g.SystemStakingContractHeight = identityset.Address(10).String(), 100
+	g.SystemSGDContractAddress = ""
+	bridge := SystemContract{Name: "bridge", Address: identityset.Address(12).String(), Height: 300, Code: "0x6080"}
+	g.SystemContracts = []SystemContract{bridge}
+
+	c, ok := g.SystemContract(SystemStakingContractName)
+	require.True(ok)
+	require.Equal(SystemContract{Name: SystemStakingContractName, Address: identityset.Address(10).String(), Height: 100}, c)
+	_, ok = g.SystemContract(SystemSGDContractName)
+	require.False(ok)
+	c, ok = g.SystemContract("bridge")
+	require.True(ok)
+	require.Equal(bridge, c)
+	_, ok = g.SystemContract("unknown")
+	require.False(ok)
+
+	g.SystemSGDContractAddress, g.SystemSGDContractHeight = identityset.Address(11).String(), 200
+	c, ok = g.SystemContract(SystemSGDContractName)
+	require.True(ok)
+	require.Equal(identityset.Address(11).String(), c.Address)
+	require.EqualValues(200, c.Height)
+
+	// the list is deep copied
+	clone := g.Clone()
+	clone.SystemContracts[0].Height = 0
+	require.EqualValues(300, g.SystemContracts[0].Height)
+}
+
+func TestPoll_ProbationWindow(t *testing.T) {
+	require := require.New(t)
+	p := Poll{ProbationEpochPeriod: 6}
+	for _, v := range []struct {
+		epoch, from, to uint64
+	}{
+		{0, 0, 0},
+		{1, 0, 0},
+		{2, 1, 1},
+		{6, 1, 5},
+		{7, 1, 6},
+		{8, 2, 7},
+		{100, 94, 99},
+	} {
+		from, to := p.ProbationWindow(v.epoch)
+		require.Equal(v.from, from, v.epoch)
+		require.Equal(v.to, to, v.epoch)
+	}
+
+	p.ProbationEpochPeriod = 0
+	from, to := p.ProbationWindow(100)
+	require.Zero(from)
+	require.Zero(to)
+}
+
+func TestPoll_SystemSGD(t *testing.T) {
+	require := require.New(t)
+	p := Poll{SystemSGDContractHeight: 100}
+	require.Nil(p.SystemSGDAddr())
+	require.False(p.SystemSGDActive(100))
+
+	p.SystemSGDContractAddress = identityset.Address(10).String()
+	require.Equal(identityset.Address(10).String(), p.SystemSGDAddr().String())
+	require.False(p.SystemSGDActive(99))
+	require.True(p.SystemSGDActive(100))
+	require.True(p.SystemSGDActive(101))
+
+	p.SystemSGDContractAddress = "io1invalid"
+	require.Panics(func() { p.SystemSGDAddr() })
+}
+
+func TestPoll_BootstrapDelegates(t *testing.T) {
+	require := require.New(t)
+	p := Poll{}
+	addrs, votes, err := p.BootstrapDelegates()
+	require.NoError(err)
+	require.Empty(addrs)
+	require.Empty(votes)
+
+	for i := 0; i < 5; i++ {
+		p.Delegates = append(p.Delegates, Delegate{
+			OperatorAddrStr: identityset.Address(i).String(),
+			VotesStr:        strconv.Itoa(i + 1),
+		})
+	}
+	addrs, votes, err = p.BootstrapDelegates()
+	require.NoError(err)
+	require.Len(addrs, 5)
+	require.Len(votes, 5)
+	for i := range addrs {
+		if i > 0 {
+			require.Less(addrs[i-1].String(), addrs[i].String())
+		}
+		for j, d := range p.Delegates {
+			if d.OperatorAddrStr == addrs[i].String() {
+				require.Equal(big.NewInt(int64(j+1)), votes[i])
+			}
+		}
+	}
+	// the genesis list is left as is
+	require.Equal(identityset.Address(0).String(), p.Delegates[0].OperatorAddrStr)
+
+	p.Delegates[2].VotesStr = "-1"
+	_, _, err = p.BootstrapDelegates()
+	require.ErrorContains(err, "is negative")
+	p.Delegates[2].OperatorAddrStr = "io1invalid"
+	_, _, err = p.BootstrapDelegates()
+	require.ErrorContains(err, "failed to decode delegate operator address io1invalid")
+}
+
+func TestPoll_Quorum(t *testing.T) {
+	require := require.New(t)
+	p := Poll{}
+	require.Zero(p.TotalDelegateVotes().Sign())
+	require.Zero(p.Quorum(2, 3).Sign())
+
+	for _, votes := range []string{"100", "200", "301"} {
+		p.Delegates = append(p.Delegates, Delegate{VotesStr: votes})
+	}
+	require.Equal(big.NewInt(601), p.TotalDelegateVotes())
+	for _, v := range []struct {
+		numerator, denominator int
+		expected               int64
+	}{
+		{2, 3, 400},
+		{1, 2, 300},
+		{1, 1, 601},
+		{0, 3, 0},
+		{-1, 3, 0},
+		{2, 0, 0},
+		{2, -3, 0},
+	} {
+		require.Equal(big.NewInt(v.expected), p.Quorum(v.numerator, v.denominator))
+	}
+
+	p.Delegates[0].VotesStr = "1e3"
+	require.Panics(func() { p.TotalDelegateVotes() })
+}
+
+func bootstrapCandidate(name, selfStake string) BootstrapCandidate {
+	return BootstrapCandidate{
+		OwnerAddress:      identityset.Address(1).String(),
+		OperatorAddress:   identityset.Address(2).String(),
+		RewardAddress:     identityset.Address(3).String(),
+		Name:              name,
+		SelfStakingTokens: selfStake,
+	}
+}
+
+func TestStaking_Validate(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	require.NoError(g.Staking.Validate())
+
+	for _, v := range []struct {
+		name   string
+		modify func(*Staking)
+		errMsg string
+	}{
+		{"negative fee", func(s *Staking) {
+			s.RegistrationConsts.Fee = "-100"
+		}, "registration fee is negative: -100"},
+		{"zero min self-stake", func(s *Staking) {
+			s.RegistrationConsts.MinSelfStake = "0"
+		}, "min self-stake is zero"},
+		{"zero min stake amount", func(s *Staking) {
+			s.MinStakeAmount = "0"
+		}, "min stake amount is zero"},
+		{"invalid min stake amount", func(s *Staking) {
+			s.MinStakeAmount = ""
+		}, "invalid min stake amount: "},
+		{"zero duration lg", func(s *Staking) {
+			s.VoteWeightCalConsts.DurationLg = 0
+		}, "duration lg 0 is not positive"},
+		{"auto stake multiplier less than 1", func(s *Staking) {
+			s.VoteWeightCalConsts.AutoStake = 0.9
+		}, "auto stake multiplier 0.9 is less than 1"},
+		{"self stake multiplier less than 1", func(s *Staking) {
+			s.VoteWeightCalConsts.SelfStake = 0
+		}, "self stake multiplier 0 is less than 1"},
+		{"negative withdraw waiting period", func(s *Staking) {
+			s.WithdrawWaitingPeriod = -time.Hour
+		}, "withdraw waiting period -1h0m0s is not positive"},
+		{"negative bootstrap self-staking tokens", func(s *Staking) {
+			s.BootstrapCandidates = []BootstrapCandidate{bootstrapCandidate("a", "1"), bootstrapCandidate("b", "-1")}
+		}, "invalid bootstrap candidate 1: self-staking tokens is negative: -1"},
+		{"invalid bootstrap operator address", func(s *Staking) {
+			c := bootstrapCandidate("a", "1")
+			c.OperatorAddress = "io1invalid"
+			s.BootstrapCandidates = []BootstrapCandidate{c}
+		}, "invalid bootstrap candidate 0: invalid operator address io1invalid"},
+		{"empty bootstrap name", func(s *Staking) {
+			s.BootstrapCandidates = []BootstrapCandidate{bootstrapCandidate("", "1")}
+		}, "invalid bootstrap candidate 0: name is empty"},
+		{"duplicate bootstrap name", func(s *Staking) {
+			s.BootstrapCandidates = []BootstrapCandidate{bootstrapCandidate("a", "1"), bootstrapCandidate("a", "1")}
+		}, "duplicate name a of bootstrap candidate 1"},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			g := TestDefault()
+			v.modify(&g.Staking)
+			require.ErrorContains(g.Staking.Validate(), v.errMsg)
+			require.ErrorContains(g.Validate(), v.errMsg)
+		})
+	}
+
+	// zero fee is valid
+	g.RegistrationConsts.Fee = "0"
+	g.BootstrapCandidates = []BootstrapCandidate{bootstrapCandidate("a", "0"), bootstrapCandidate("b", "1")}
+	require.NoError(g.Validate())
+
+	// negative init balance
+	g.InitBalanceMap[identityset.Address(0).String()] = "-1"
+	require.ErrorContains(g.Validate(), "is negative: -1")
+}
+
+func TestNew_InvalidStaking(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "genesis.yaml")
+	for _, v := range []struct {
+		src, errMsg string
+	}{
+		{"staking:\n  withdrawWaitingPeriod: 0s\n", "invalid staking config: withdraw waiting period 0s is not positive"},
+		{"staking:\n  withdrawWaitingPeriod: -72h\n", "withdraw waiting period -72h0m0s is not positive"},
+		{"staking:\n  minStakeAmount: 0\n", "invalid staking config: min stake amount is zero"},
+		{"staking:\n  registrationConsts:\n    fee: -1\n", "registration fee is negative: -1"},
+		{"staking:\n  withdrawWaitingPeriod: 24h\n", ""},
+	} {
+		require.NoError(os.WriteFile(path, []byte(v.src), 0600))
+		g, err := New(path)
+		if v.errMsg != "" {
+			require.ErrorContains(err, v.errMsg)
+			continue
+		}
+		require.NoError(err)
+		require.Equal(24*time.Hour, g.WithdrawWaitingPeriod)
+	}
+}
+
+func TestGenesis_IsSystemContractEnabled(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	g.SystemStakingContractAddress, g.SystemStakingContractHeight = identityset.Address(1).String(), 100
+	g.SystemSGDContractAddress, g.SystemSGDContractHeight = identityset.Address(2).String(), 200
+	for _, v := range []struct {
+		height       uint64
+		staking, sgd bool
+	}{
+		{0, false, false},
+		{99, false, false},
+		{100, true, false},
+		{199, true, false},
+		{200, true, true},
+	} {
+		require.Equal(v.staking, g.IsSystemStakingEnabled(v.height), v.height)
+		require.Equal(v.sgd, g.IsSystemSGDEnabled(v.height), v.height)
+	}
+
+	// contracts not configured
+	g.SystemStakingContractAddress, g.SystemSGDContractAddress = "", ""
+	require.False(g.IsSystemStakingEnabled(1000))
+	require.False(g.IsSystemSGDEnabled(1000))
+}
+
+func TestGenesis_ContractBucketWeight(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	amount, ok := new(big.Int).SetString("100000000000000000000", 10)
+	require.True(ok)
+	for _, v := range []struct {
+		days      uint32
+		autoStake bool
+		height    uint64
+		expected  string
+	}{
+		{91, true, g.RedseaBlockHeight - 1, "100000000000000000000"},
+		{91, true, g.RedseaBlockHeight, "128543013665454552985"},
+		{91, false, g.RedseaBlockHeight, "124741229648530627117"},
+		{0, false, g.RedseaBlockHeight, "100000000000000000000"},
+	} {
+		require.Equal(v.expected, g.ContractBucketWeight(amount, v.days, v.autoStake, v.height).String())
+	}
+	// the amount is not modified
+	require.Equal("100000000000000000000", amount.String())
+}
+
+func TestVoteWeightCalConsts_durationMultiplier(t *testing.T) {
+	require := require.New(t)
+	c := Default.VoteWeightCalConsts
+	for _, v := range []struct {
+		days               uint32
+		expected, withAuto float64
+	}{
+		{0, 1, 1},
+		{1, 1, 1.0380178401692393},
+		{7, 1.1067295707251132, 1.1447474108943525},
+		{91, 1.2474122964853063, 1.2854301366545455},
+		{1050, 1.3815536443127954, 1.4195714844820349},
+	} {
+		require.InDelta(v.expected, c.durationMultiplier(v.days, false), 1e-12)
+		require.InDelta(v.withAuto, c.durationMultiplier(v.days, true), 1e-12)
+	}
+}
+
+func TestVoteWeightCalConsts_Weight(t *testing.T) {
+	require := require.New(t)
+	c := Default.VoteWeightCalConsts
+	day := 24 * time.Hour
+
+	// the duration is rounded up to days
+	require.Equal(c.durationMultiplier(91, false), c.Weight(90*day+time.Second, false, false))
+	require.Equal(1.0, c.Weight(0, true, true))
+	// the self-stake bonus requires auto-stake for at least 91 days
+	require.Equal(c.durationMultiplier(91, true)*c.SelfStake, c.Weight(91*day, true, true))
+	require.Equal(c.durationMultiplier(91, false), c.Weight(91*day, false, true))
+	require.Equal(c.durationMultiplier(91, true), c.Weight(90*day+time.Second, true, true))
+
+	// golden values of the weighted votes, which must match the consensus
+	selfStake, ok := new(big.Int).SetString("1200000000000000000000000", 10)
+	require.True(ok)
+	for _, v := range []struct {
+		amount    *big.Int
+		days      time.Duration
+		autoStake bool
+		selfStake bool
+		expected  string
+	}{
+		{big.NewInt(100), 100, true, true, "136"},
+		{big.NewInt(100), 100, true, false, "129"},
+		{big.NewInt(100), 100, false, true, "125"},
+		{big.NewInt(10000), 91, false, false, "12474"},
+		{big.NewInt(100000), 91, true, false, "128543"},
+		{selfStake, 91, true, true, "1635067133824581908640994"},
+		{selfStake, 90, true, true, "1541788888305218563345988"},
+		{selfStake, 1050, true, true, "1805694928261148479720078"},
+	} {
+		require.Equal(v.expected, c.VoteWeight(v.amount, v.days*day, v.autoStake, v.selfStake).String())
+	}
+}
+
+func TestBlockchain_NumBlocksByEpoch(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	require.EqualValues(1816201, g.DardanellesBlockHeight)
+	// the epoch which dardanelles height falls in starts at 1816177
+	for _, v := range []struct {
+		height                uint64
+		numSubEpochs, numBlks uint64
+	}{
+		{1, 2, 48},
+		{1816176, 2, 48},
+		{1816177, 2, 720},
+		{1816200, 2, 720},
+		{1816201, 30, 720},
+		{1816897, 30, 720},
+	} {
+		require.Equal(v.numSubEpochs, g.NumSubEpochsByHeight(v.height))
+		require.Equal(v.numBlks, g.NumBlocksByEpoch(v.height))
+	}
+
+	// dardanelles from the genesis
+	g.DardanellesBlockHeight = 0
+	require.EqualValues(30, g.NumSubEpochsByHeight(1))
+	require.EqualValues(720, g.NumBlocksByEpoch(1))
+}
+
+func TestGenesis_LogFields(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	g.InitBalanceMap = map[string]string{
+		identityset.Address(0).String(): "100",
+		identityset.Address(1).String(): "23",
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range g.LogFields() {
+		f.AddTo(enc)
+	}
+	require.Equal(g.HashHex(), enc.Fields["hash"])
+	require.Equal("mainnet", enc.Fields["chainName"])
+	require.EqualValues(1, enc.Fields["chainID"])
+	require.Equal(10*time.Second, enc.Fields["blockInterval"])
+	require.EqualValues(24, enc.Fields["numDelegates"])
+	require.EqualValues(g.DardanellesBlockHeight, enc.Fields["dardanellesHeight"])
+	require.EqualValues(uint64(math.MaxUint64), enc.Fields["toBeEnabledHeight"])
+	require.EqualValues(2, enc.Fields["numInitBalances"])
+	require.Equal("123", enc.Fields["initTotalSupply"])
+	for _, f := range Forks() {
+		require.Contains(enc.Fields, f.String()+"Height")
+	}
+
+	g.InitBalanceMap[identityset.Address(2).String()] = "-1"
+	enc = zapcore.NewMapObjectEncoder()
+	for _, f := range g.LogFields() {
+		f.AddTo(enc)
+	}
+	require.EqualValues(3, enc.Fields["numInitBalances"])
+	require.NotContains(enc.Fields, "initTotalSupply")
+	require.Contains(enc.Fields, "initBalancesError")
+}
+
+func TestBlockchain_ProposerSlotAtRound(t *testing.T) {
+	require := require.New(t)
+	g := TestDefault()
+	for _, v := range []struct {
+		height              uint64
+		round               uint32
+		delegates           int
+		fixed, timeRotation int
+	}{
+		{1, 0, 24, 1, 1},
+		{24, 0, 24, 0, 0},
+		{25, 1, 24, 1, 2},
+		{100, 3, 24, 4, 7},
+		{23, 2, 24, 23, 1},
+		{10, 5, 0, -1, -1},
+		{10, 5, -1, -1, -1},
+	} {
+		g.TimeBasedRotation = false
+		require.Equal(v.fixed, g.ProposerSlotAtRound(v.height, v.round, v.delegates))
+		require.Equal(g.ProposerSlotAtRound(v.height, 0, v.delegates), g.ProposerSlot(v.height, v.delegates))
+		g.TimeBasedRotation = true
+		require.Equal(v.timeRotation, g.ProposerSlotAtRound(v.height, v.round, v.delegates))
+		require.Equal(g.ProposerSlotAtRound(v.height, 0, v.delegates), g.ProposerSlot(v.height, v.delegates))
+	}
+}
+
+func TestStaking_MinSelfStakeAmount(t *testing.T) {
+	require := require.New(t)
+	cfg := TestDefault()
+	require.Equal(unit.ConvertIotxToRau(1200000), cfg.MinSelfStakeAmount())
+	cfg.RegistrationConsts.MinSelfStake = "abc"
+	require.Panics(func() { cfg.MinSelfStakeAmount() })
+}
+
+func TestGenesis_Clone(t *testing.T) {
+	require := require.New(t)
+	cfg := TestDefault()
+	cfg.ExemptAddrStrsFromEpochReward = []string{"io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6"}
+	cfg.BootstrapCandidates = []BootstrapCandidate{{Name: "test"}}
+	clone := cfg.Clone()
+	require.Equal(cfg, clone)
+	require.Equal(cfg.Hash(), clone.Hash())
+
+	for addr := range clone.InitBalanceMap {
+		clone.InitBalanceMap[addr] = "0"
+	}
+	clone.Delegates[0].VotesStr = "0"
+	clone.ExemptAddrStrsFromEpochReward[0] = ""
+	clone.BootstrapCandidates[0].Name = ""
+	require.Equal(TestDefault().InitBalanceMap, cfg.InitBalanceMap)
+	require.Equal(TestDefault().Delegates, cfg.Delegates)
+	require.Equal("io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6", cfg.ExemptAddrStrsFromEpochReward[0])
+	require.Equal("test", cfg.BootstrapCandidates[0].Name)
+}
+
+func TestDefaultConfig_Copy(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultConfig()
+	require.Equal(Default, cfg)
+
+	addr := identityset.Address(0).String()
+	balance := Default.InitBalanceMap[addr]
+	cfg.InitBalanceMap[addr] = "0"
+	cfg.InitBalanceMap["io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6"] = "1"
+	cfg.Delegates[0].VotesStr = "0"
+	require.Equal(balance, Default.InitBalanceMap[addr])
+	require.NotContains(Default.InitBalanceMap, "io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6")
+	require.NotEqual("0", Default.Delegates[0].VotesStr)
+	require.Equal(Default, DefaultConfig())
+}
+
+func TestGetDefault(t *testing.T) {
+	require := require.New(t)
+	cfg := GetDefault()
+	require.Equal(Default, cfg)
+	addr := identityset.Address(0).String()
+	balance := Default.InitBalanceMap[addr]
+	cfg.InitBalanceMap[addr] = "0"
+	require.Equal(balance, Default.InitBalanceMap[addr])
+	require.Equal(Default, GetDefault())
+}
+
+func TestAccount_NormalizeAddresses(t *testing.T) {
+	require := require.New(t)
+	addr := identityset.Address(1)
+	// the legacy form of addr, which is prefixed with an extra zero byte
+	grouped, err := bech32.ConvertBits(append([]byte{0}, addr.Bytes()...), 8, 5, true)
+	require.NoError(err)
+	legacy, err := bech32.Encode("io", grouped)
+	require.NoError(err)
+
+	acc := Account{InitBalanceMap: map[string]string{
+		addr.String():                   "2",
+		legacy:                          "3",
+		identityset.Address(2).String(): "1",
+	}}
+	require.NoError(acc.NormalizeAddresses())
+	require.Equal(map[string]string{
+		addr.String():                   "5",
+		identityset.Address(2).String(): "1",
+	}, acc.InitBalanceMap)
+
+	acc.InitBalanceMap["invalid"] = "1"
+	require.Error(acc.NormalizeAddresses())
+}
+
+func TestAccount_EachInitBalance(t *testing.T) {
+	require := require.New(t)
+	acc := Account{InitBalanceMap: map[string]string{
+		"io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms": "2",
+		"io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6": "1",
+	}}
+	var (
+		addrs   []string
+		amounts []string
+	)
+	require.NoError(acc.EachInitBalance(func(addr address.Address, amount *big.Int) error {
+		addrs = append(addrs, addr.String())
+		amounts = append(amounts, amount.String())
+		return nil
+	}))
+	require.Equal([]string{"io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6", "io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms"}, addrs)
+	require.Equal([]string{"1", "2"}, amounts)
+
+	// stop on the first error
+	errStop := errors.New("stop")
+	count := 0
+	require.Equal(errStop, acc.EachInitBalance(func(address.Address, *big.Int) error {
+		count++
+		return errStop
+	}))
+	require.Equal(1, count)
+
+	acc.InitBalanceMap["io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms"] = "x"
+	require.Error(acc.EachInitBalance(func(address.Address, *big.Int) error { return nil }))
+	require.Panics(func() { acc.InitBalances() })
+	delete(acc.InitBalanceMap, "io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms")
+	acc.InitBalanceMap["invalid"] = "1"
+	require.Error(acc.EachInitBalance(func(address.Address, *big.Int) error { return nil }))
+}
+
+func TestNewStrict(t *testing.T) {
+	require := require.New(t)
+
+	for _, v := range []struct {
+		yaml    string
+		success bool
+	}{
+		{"blockchain:\n  sumatraHeight: 30000000\n", true},
+		// misspelled key
+		{"blockchain:\n  summatraHeight: 30000000\n", false},
+		// unknown section
+		{"blockchian:\n  sumatraHeight: 30000000\n", false},
+		// sumatra prior to redsea
+		{"blockchain:\n  sumatraHeight: 1\n", false},
+	} {
+		path := filepath.Join(t.TempDir(), "genesis.yaml")
+		require.NoError(os.WriteFile(path, []byte(v.yaml), 0644))
+		g, err := NewStrict(path)
+		if v.success {
+			require.NoError(err)
+			require.EqualValues(30000000, g.SumatraBlockHeight)
+		} else {
+			require.Error(err)
+		}
+	}
+
+	// unknown keys are named by their full paths
+	path := filepath.Join(t.TempDir(), "genesis.yaml")
+	require.NoError(os.WriteFile(path, []byte(`blockchain:
+  okhotskHeght: 10
+poll:
+  delegates:
+    - operatorAddr: io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms
+      votez: "1"
+staking:
+  voteWeightCalConsts:
+    durationLg: 1.2
+    durationLgg: 1.2
+`), 0644))
+	_, err := NewStrict(path)
+	require.Equal(ErrUnknownKeys, errors.Cause(err))
+	require.Equal("blockchain.okhotskHeght, poll.delegates[0].votez, staking.voteWeightCalConsts.durationLgg: "+ErrUnknownKeys.Error(), err.Error())
+}
+
+func TestNewVerified(t *testing.T) {
+	require := require.New(t)
+	expected, err := hex.DecodeString("3dfcdee76186b59a9f9abd0ded8e6c093c35bddea23834044550fb68626adb62")
+	require.NoError(err)
+
+	g, err := NewVerified("", hash.BytesToHash256(expected))
+	require.NoError(err)
+	require.Equal(hash.BytesToHash256(expected), g.Hash())
+
+	_, err = NewVerified("", hash.ZeroHash256)
+	require.Equal(ErrGenesisHashMismatch, errors.Cause(err))
+	require.Contains(err.Error(), hex.EncodeToString(expected))
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownProfile is the error that the genesis profile is not built in
+var ErrUnknownProfile = errors.New("unknown genesis profile")
+
+// _profiles are the built-in genesis profiles by name
+var _profiles = map[string]func() Genesis{
+	_mainnetChainName: Mainnet,
+	_testnetChainName: Testnet,
+	_devnetChainName:  Devnet,
+}
+
+// Mainnet returns the genesis config of the mainnet
+func Mainnet() Genesis {
+	return defaultConfig()
+}
+
+// Testnet returns the genesis config of the public testnet, which identifies itself by the testnet chain ID and name.
+// The testnet runs the protocol parameters and the fork schedule of the mainnet, as no separate schedule of the testnet
+// is built in. A testnet node whose heights differ overrides them in its genesis yaml.
+func Testnet() Genesis {
+	g := defaultConfig()
+	g.ChainName = _testnetChainName
+	g.ChainID = _testnetChainID
+	return g
+}
+
+// Devnet returns the genesis config of a local development network, in which all the forks are activated from the
+// genesis, so the newest rules apply immediately. ToBeEnabled stays disabled, as it gates the features under
+// development.
+func Devnet() Genesis {
+	g := defaultConfig()
+	g.ChainName = _devnetChainName
+	g.ChainID = _devnetChainID
+	for _, f := range Forks() {
+		if f != ToBeEnabled {
+			*g.forkHeight(f) = 0
+		}
+	}
+	return g
+}
+
+// ByName returns the built-in genesis config of the profile name, i.e., mainnet, testnet or devnet
+func ByName(name string) (Genesis, error) {
+	profile, ok := _profiles[name]
+	if !ok {
+		return Genesis{}, errors.Wrap(ErrUnknownProfile, name)
+	}
+	return profile(), nil
+}
+
+// ProfileNames returns the names of the built-in genesis profiles in alphabetical order
+func ProfileNames() []string {
+	names := make([]string, 0, len(_profiles))
+	for name := range _profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByName(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal([]string{"devnet", "mainnet", "testnet"}, ProfileNames())
+	g, err := ByName("mainnet")
+	require.NoError(err)
+	require.Equal(Mainnet(), g)
+	require.EqualValues(1, g.ChainID)
+	require.Equal("mainnet", g.NetworkName())
+	require.Empty(g.InitBalanceMap)
+
+	g, err = ByName("testnet")
+	require.NoError(err)
+	require.Equal(Testnet(), g)
+	require.EqualValues(2, g.ChainID)
+	require.Equal("testnet", g.NetworkName())
+	require.Equal(Mainnet().Blockchain.PacificBlockHeight, g.PacificBlockHeight)
+	require.NoError(g.Validate())
+
+	g, err = ByName("devnet")
+	require.NoError(err)
+	require.Equal(Devnet(), g)
+	require.EqualValues(1337, g.ChainID)
+	require.Equal("devnet", g.NetworkName())
+	require.NoError(g.Validate())
+	for _, f := range Forks() {
+		h, err := g.ForkHeight(f)
+		require.NoError(err)
+		if f == ToBeEnabled {
+			require.Equal(uint64(math.MaxUint64), h)
+		} else {
+			require.Zero(h)
+		}
+	}
+	require.True(g.IsSumatra(1))
+
+	_, err = ByName("localnet")
+	require.Equal(ErrUnknownProfile, errors.Cause(err))
+
+	// New falls back to the profile if no file exists at the path
+	g, err = New("testnet")
+	require.NoError(err)
+	require.EqualValues(2, g.ChainID)
+	g, err = New("devnet")
+	require.NoError(err)
+	require.EqualValues(1337, g.ChainID)
+	_, err = New("localnet")
+	require.Error(err)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// _amountPattern matches an amount in Rau, e.g., a balance or a reward
+	_amountPattern = `^[0-9]+$`
+	// _iotxPattern matches an amount in IOTX with at most 18 decimals, e.g., 1.5
+	_iotxPattern = `^[0-9]+(\.[0-9]{1,18})?$`
+	// _durationPattern matches a duration in the format of time.ParseDuration, e.g., 10s or 1h30m
+	_durationPattern = `^(0|-?(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|ms|s|m|h))+)$`
+)
+
+// GenesisJSONSchema returns the JSON Schema of the genesis config, which is generated from the Genesis struct, so that
+// a genesis file could be validated before it is loaded. The properties are named by the yaml keys, and unknown keys
+// are not allowed. The durations are strings like 10s, and the amounts, i.e., the string fields tagged by
+// schema:"amount" or schema:"iotx", are decimal strings in Rau or IOTX respectively. No key is required, as the
+// missing ones take the default values.
+func GenesisJSONSchema() ([]byte, error) {
+	schema, err := jsonSchema(reflect.TypeOf(Genesis{}), "")
+	if err != nil {
+		return nil, err
+	}
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "IoTeX genesis config"
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// jsonSchema returns the JSON Schema of the type t, where format is the schema tag of the field, which applies to the
+// elements of a slice or map as well
+func jsonSchema(t reflect.Type, format string) (map[string]interface{}, error) {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		// a duration could be given in nanoseconds as well
+		return map[string]interface{}{
+			"type":    []string{"string", "integer"},
+			"pattern": _durationPattern,
+		}, nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return jsonSchema(t.Elem(), format)
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.String:
+		switch format {
+		case "amount":
+			// an amount could be given as a number in the yaml file as well
+			return map[string]interface{}{"type": []string{"string", "integer"}, "pattern": _amountPattern}, nil
+		case "iotx":
+			return map[string]interface{}{"type": []string{"string", "number"}, "pattern": _iotxPattern}, nil
+		case "":
+			return map[string]interface{}{"type": "string"}, nil
+		default:
+			return nil, errors.Errorf("unknown schema format %s", format)
+		}
+	case reflect.Slice, reflect.Array:
+		items, err := jsonSchema(t.Elem(), format)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, errors.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := jsonSchema(t.Elem(), format)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		properties := make(map[string]interface{}, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			property, err := jsonSchema(f.Type, f.Tag.Get("schema"))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to generate the schema of %s.%s", t.Name(), f.Name)
+			}
+			properties[yamlKey(f)] = property
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}, nil
+	default:
+		return nil, errors.Errorf("unsupported type %s", t)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenesisJSONSchema(t *testing.T) {
+	require := require.New(t)
+	b, err := GenesisJSONSchema()
+	require.NoError(err)
+	var schema map[string]interface{}
+	require.NoError(json.Unmarshal(b, &schema))
+	require.Equal("object", schema["type"])
+	require.Equal(false, schema["additionalProperties"])
+
+	property := func(path ...string) map[string]interface{} {
+		s := schema
+		for _, key := range path {
+			switch key {
+			case "[]":
+				s = s["items"].(map[string]interface{})
+			case "{}":
+				s = s["additionalProperties"].(map[string]interface{})
+			default:
+				s = s["properties"].(map[string]interface{})[key].(map[string]interface{})
+			}
+		}
+		return s
+	}
+	require.Equal("integer", property("blockchain", "timestamp")["type"])
+	require.Equal("string", property("blockchain", "chainName")["type"])
+	require.EqualValues(0, property("blockchain", "numDelegates")["minimum"])
+	require.Equal(_durationPattern, property("blockchain", "blockInterval")["pattern"])
+	require.Equal("boolean", property("poll", "enableGravityChainVoting")["type"])
+	require.Equal(_amountPattern, property("rewarding", "blockReward")["pattern"])
+	require.Equal(_amountPattern, property("account", "initBalances", "{}")["pattern"])
+	require.Equal(_iotxPattern, property("account", "initBalancesIotx", "{}")["pattern"])
+	require.Equal(_amountPattern, property("poll", "delegates", "[]", "votes")["pattern"])
+	require.Equal("string", property("poll", "delegates", "[]", "operatorAddr")["type"])
+	require.Equal("number", property("staking", "voteWeightCalConsts", "durationLg")["type"])
+	require.Equal(_amountPattern, property("staking", "registrationConsts", "fee")["pattern"])
+
+	for _, v := range []struct {
+		pattern string
+		valid   []string
+		invalid []string
+	}{
+		{_amountPattern, []string{"0", "16000000000000000000"}, []string{"", "-1", "1.5", "1e18"}},
+		{_iotxPattern, []string{"0", "1", "1.5", "0.000000000000000001"}, []string{".5", "1.", "-1", "0.0000000000000000001"}},
+		{_durationPattern, []string{"0", "10s", "1h30m", "1.5s", "-2ms", "300µs", ".5s"}, []string{"", "10", "1d", "s", ".s"}},
+	} {
+		re := regexp.MustCompile(v.pattern)
+		for _, s := range v.valid {
+			require.True(re.MatchString(s), s)
+		}
+		for _, s := range v.invalid {
+			require.False(re.MatchString(s), s)
+		}
+	}
+
+	// every key of the genesis config is described by the schema
+	g := TestDefault()
+	g.SystemContracts = []SystemContract{{Name: "bridge"}}
+	src, err := yaml.Marshal(g)
+	require.NoError(err)
+	var raw interface{}
+	require.NoError(yaml.Unmarshal(src, &raw))
+	require.Empty(keysNotInSchema(schema, raw, ""))
+}
+
+func keysNotInSchema(schema map[string]interface{}, v interface{}, path string) []string {
+	var keys []string
+	if m, ok := yamlMap(v); ok {
+		properties, _ := schema["properties"].(map[string]interface{})
+		for key, value := range m {
+			if properties == nil {
+				// a map, such as the init balances
+				values, _ := schema["additionalProperties"].(map[string]interface{})
+				keys = append(keys, keysNotInSchema(values, value, path+key+".")...)
+				continue
+			}
+			property, ok := properties[key].(map[string]interface{})
+			if !ok {
+				keys = append(keys, path+key)
+				continue
+			}
+			keys = append(keys, keysNotInSchema(property, value, path+key+".")...)
+		}
+	}
+	if s, ok := v.([]interface{}); ok {
+		items, _ := schema["items"].(map[string]interface{})
+		for _, value := range s {
+			keys = append(keys, keysNotInSchema(items, value, path)...)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package genesis
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownKeys is the error that the yaml config has keys which don't match any genesis field
+var ErrUnknownKeys = errors.New("unknown keys in genesis config")
+
+// checkUnknownKeys returns ErrUnknownKeys naming the full paths of the keys in the yaml source which don't match any
+// genesis field, e.g., blockchain.okhotskHeght
+func checkUnknownKeys(src []byte) error {
+	var raw interface{}
+	if err := yaml.Unmarshal(src, &raw); err != nil {
+		return errors.Wrap(err, "failed to parse genesis yaml")
+	}
+	var keys []string
+	collectUnknownKeys(reflect.TypeOf(Genesis{}), raw, "", &keys)
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	return errors.Wrap(ErrUnknownKeys, strings.Join(keys, ", "))
+}
+
+// collectUnknownKeys walks the yaml value v along the type t. A value not matching the kind of t is skipped, which is
+// left to the unmarshalling to report.
+func collectUnknownKeys(t reflect.Type, v interface{}, path string, keys *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Duration(0)) {
+			return
+		}
+		m, ok := yamlMap(v)
+		if !ok {
+			return
+		}
+		for key, value := range m {
+			f, ok := fieldByYAMLKey(t, key)
+			if !ok {
+				*keys = append(*keys, path+key)
+				continue
+			}
+			collectUnknownKeys(f.Type, value, path+key+".", keys)
+		}
+	case reflect.Slice:
+		s, ok := v.([]interface{})
+		if !ok {
+			return
+		}
+		prefix := strings.TrimSuffix(path, ".")
+		for i, value := range s {
+			collectUnknownKeys(t.Elem(), value, fmt.Sprintf("%s[%d].", prefix, i), keys)
+		}
+	case reflect.Map:
+		m, ok := yamlMap(v)
+		if !ok {
+			return
+		}
+		for k, value := range m {
+			collectUnknownKeys(t.Elem(), value, path+k+".", keys)
+		}
+	}
+}
+
+func fieldByYAMLKey(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath == "" && yamlKey(f) == key {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// yamlMap returns the yaml mapping v keyed by strings, as a mapping with a non-string key, e.g., a number, is decoded
+// into map[interface{}]interface{} rather than map[string]interface{}
+func yamlMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		sm := make(map[string]interface{}, len(m))
+		for k, value := range m {
+			sm[fmt.Sprint(k)] = value
+		}
+		return sm, true
+	default:
+		return nil, false
+	}
+}
@@ -80,7 +80,7 @@ var (
 		},
 		DB:       db.DefaultConfig,
 		Indexer:  blockindex.DefaultConfig,
-		Genesis:  genesis.Default,
+		Genesis:  genesis.GetDefault(),
 		NodeInfo: nodeinfo.DefaultConfig,
 	}
 
@@ -285,6 +285,21 @@ func ValidateActPool(cfg Config) error {
 	return nil
 }
 
+// SyncChainID takes the chain ID of the node as the one of the genesis if the genesis leaves the network identity as
+// the default one of mainnet, and validates that the two chain IDs match otherwise. It's not in Validates, as the
+// genesis is loaded separately from the config and set to it afterwards.
+func SyncChainID(cfg *Config) error {
+	mainnet := genesis.Mainnet()
+	if cfg.Genesis.ChainID == mainnet.ChainID && cfg.Genesis.ChainName == mainnet.ChainName && cfg.Chain.ID != mainnet.ChainID {
+		// the name is then derived from the chain ID, see genesis.Blockchain.NetworkName
+		cfg.Genesis.ChainID, cfg.Genesis.ChainName = cfg.Chain.ID, ""
+	}
+	if cfg.Chain.ID != cfg.Genesis.ChainID {
+		return errors.Wrapf(ErrInvalidCfg, "chain ID %d mismatches the chain ID %d of genesis", cfg.Chain.ID, cfg.Genesis.ChainID)
+	}
+	return nil
+}
+
 // ValidateForkHeights validates the forked heights
 func ValidateForkHeights(cfg Config) error {
 	hu := cfg.Genesis
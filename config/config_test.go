@@ -309,6 +309,24 @@ func TestValidateActPool(t *testing.T) {
 	)
 }
 
+func TestSyncChainID(t *testing.T) {
+	r := require.New(t)
+	cfg := Default
+	r.NoError(SyncChainID(&cfg))
+	r.EqualValues(1, cfg.Genesis.ChainID)
+	r.Equal("mainnet", cfg.Genesis.NetworkName())
+	// the genesis leaving the network identity as default takes the chain ID of the node
+	h := cfg.Genesis.Hash()
+	cfg.Chain.ID = 2
+	r.NoError(SyncChainID(&cfg))
+	r.EqualValues(2, cfg.Genesis.ChainID)
+	r.Equal("testnet", cfg.Genesis.NetworkName())
+	r.Equal(h, cfg.Genesis.Hash())
+	// the genesis setting another chain ID
+	cfg.Genesis.ChainID, cfg.Genesis.ChainName = 3, "devnet"
+	r.ErrorIs(SyncChainID(&cfg), ErrInvalidCfg)
+}
+
 func TestValidateForkHeights(t *testing.T) {
 	r := require.New(t)
 
@@ -175,6 +175,52 @@ func (b *BoltDB) Filter(namespace string, cond Condition, minKey, maxKey []byte)
 	return fk, fv, nil
 }
 
+// Buckets returns the names of all buckets
+func (b *BoltDB) Buckets() ([]string, error) {
+	if !b.IsReady() {
+		return nil, ErrDBNotStarted
+	}
+
+	var names []string
+	if err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+			names = append(names, string(name))
+			return nil
+		})
+	}); err != nil {
+		return nil, errors.Wrap(ErrIO, err.Error())
+	}
+	return names, nil
+}
+
+// Page returns at most count <k, v> pairs of a bucket in key order, starting from the key
+func (b *BoltDB) Page(namespace string, key []byte, count uint64) ([][]byte, [][]byte, error) {
+	if !b.IsReady() {
+		return nil, nil, ErrDBNotStarted
+	}
+
+	var fk, fv [][]byte
+	if err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(namespace))
+		if bucket == nil {
+			return errors.Wrapf(ErrBucketNotExist, "bucket = %x doesn't exist", []byte(namespace))
+		}
+		c := bucket.Cursor()
+		for k, v := c.Seek(key); k != nil && uint64(len(fk)) < count; k, v = c.Next() {
+			key := make([]byte, len(k))
+			copy(key, k)
+			value := make([]byte, len(v))
+			copy(value, v)
+			fk = append(fk, key)
+			fv = append(fv, value)
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	return fk, fv, nil
+}
+
 // Range retrieves values for a range of keys
 func (b *BoltDB) Range(namespace string, key []byte, count uint64) ([][]byte, error) {
 	if !b.IsReady() {
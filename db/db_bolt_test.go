@@ -80,6 +80,57 @@ func TestBucketExists(t *testing.T) {
 	r.Equal([]byte{}, v)
 }
 
+func TestBoltDB_BucketsAndPage(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-page")
+	r.NoError(err)
+	defer func() {
+		testutil.CleanupPath(testPath)
+	}()
+
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	kv := NewBoltDB(cfg)
+	ctx := context.Background()
+	r.NoError(kv.Start(ctx))
+	defer kv.Stop(ctx)
+	buckets, err := kv.Buckets()
+	r.NoError(err)
+	r.Empty(buckets)
+	for i := byte(0); i < 5; i++ {
+		r.NoError(kv.Put("ns1", []byte{i}, []byte{i + 10}))
+	}
+	r.NoError(kv.Put("ns2", []byte("key"), []byte("value")))
+	buckets, err = kv.Buckets()
+	r.NoError(err)
+	r.Equal([]string{"ns1", "ns2"}, buckets)
+
+	keys, values, err := kv.Page("ns1", nil, 2)
+	r.NoError(err)
+	r.Equal([][]byte{{0}, {1}}, keys)
+	r.Equal([][]byte{{10}, {11}}, values)
+	keys, values, err = kv.Page("ns1", []byte{1, 0}, 10)
+	r.NoError(err)
+	r.Equal([][]byte{{2}, {3}, {4}}, keys)
+	r.Equal([][]byte{{12}, {13}, {14}}, values)
+	keys, _, err = kv.Page("ns1", []byte{5}, 10)
+	r.NoError(err)
+	r.Empty(keys)
+	_, _, err = kv.Page("ns3", nil, 10)
+	r.ErrorIs(err, ErrBucketNotExist)
+
+	// the kvstore with cache reads the underlying kvstore
+	kvc := NewKvStoreWithCache(kv, 10).(KVStoreWithBuckets)
+	buckets, err = kvc.Buckets()
+	r.NoError(err)
+	r.Len(buckets, 2)
+	keys, _, err = kvc.Page("ns2", nil, 10)
+	r.NoError(err)
+	r.Equal([][]byte{[]byte("key")}, keys)
+	_, err = NewKvStoreWithCache(NewMemKVStore(), 10).(KVStoreWithBuckets).Buckets()
+	r.ErrorIs(err, ErrNotSupported)
+}
+
 func TestDiskfullErr(t *testing.T) {
 	err := fmt.Errorf("write /run/data/chain.db: %w", syscall.ENOSPC)
 	require.True(t, errors.Is(err, syscall.ENOSPC))
@@ -43,6 +43,15 @@ type (
 		Range(string, []byte, uint64) ([][]byte, error)
 	}
 
+	// KVStoreWithBuckets is KVStore which lists its buckets and reads a bucket page by page
+	KVStoreWithBuckets interface {
+		KVStore
+		// Buckets returns the names of all buckets
+		Buckets() ([]string, error)
+		// Page returns at most count <k, v> pairs of a bucket in key order, starting from the key
+		Page(string, []byte, uint64) ([][]byte, [][]byte, error)
+	}
+
 	// KVStoreForRangeIndex is KVStore for range index
 	KVStoreForRangeIndex interface {
 		KVStore
@@ -70,6 +70,24 @@ func (kvc *kvStoreWithCache) Filter(namespace string, cond Condition, minKey, ma
 	return kvc.store.Filter(namespace, cond, minKey, maxKey)
 }
 
+// Buckets returns the names of all buckets in kvstore
+func (kvc *kvStoreWithCache) Buckets() ([]string, error) {
+	store, ok := kvc.store.(KVStoreWithBuckets)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return store.Buckets()
+}
+
+// Page returns at most count <k, v> pairs of a bucket in kvstore, starting from the key
+func (kvc *kvStoreWithCache) Page(namespace string, key []byte, count uint64) ([][]byte, [][]byte, error) {
+	store, ok := kvc.store.(KVStoreWithBuckets)
+	if !ok {
+		return nil, nil, ErrNotSupported
+	}
+	return store.Page(namespace, key, count)
+}
+
 // Delete deletes a record from statecaches if exists, and from kvstore
 func (kvc *kvStoreWithCache) Delete(namespace string, key []byte) (err error) {
 	if err := kvc.store.Delete(namespace, key); err != nil {
@@ -0,0 +1,151 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/triepb"
+)
+
+func (mpt *merklePatriciaTrie) Proof(key []byte) ([][]byte, error) {
+	mpt.mutex.RLock()
+	defer mpt.mutex.RUnlock()
+
+	kt, err := mpt.checkKeyType(key)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		proof  [][]byte
+		n      node = mpt.root
+		offset uint8
+	)
+	for {
+		if hn, ok := n.(*hashNode); ok {
+			if n, err = hn.LoadNode(mpt); err != nil {
+				return nil, err
+			}
+		}
+		sn, ok := n.(serializable)
+		if !ok {
+			return nil, trie.ErrInvalidTrie
+		}
+		pb, err := sn.proto(mpt, false)
+		if err != nil {
+			return nil, err
+		}
+		ser, err := proto.Marshal(pb)
+		if err != nil {
+			return nil, err
+		}
+		proof = append(proof, ser)
+		switch node := n.(type) {
+		case *branchNode:
+			if n, err = node.child(kt[offset]); err != nil {
+				return nil, errors.Wrapf(err, "key %x does not exist", kt)
+			}
+			offset++
+		case *extensionNode:
+			if node.commonPrefixLength(kt[offset:]) != uint8(len(node.path)) {
+				return nil, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", kt)
+			}
+			n = node.child
+			offset += uint8(len(node.path))
+		case *leafNode:
+			if !bytes.Equal(node.key, kt) {
+				return nil, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", kt)
+			}
+			// reverse the nodes into the order from leaf to root
+			for i, j := 0, len(proof)-1; i < j; i, j = i+1, j-1 {
+				proof[i], proof[j] = proof[j], proof[i]
+			}
+			return proof, nil
+		default:
+			return nil, trie.ErrInvalidTrie
+		}
+	}
+}
+
+func (tlt *twoLayerTrie) Proof(layerOneKey []byte, layerTwoKey []byte) ([][]byte, error) {
+	lt, err := tlt.layerTwoTrie(layerOneKey, len(layerTwoKey))
+	if err != nil {
+		return nil, err
+	}
+	proof, err := lt.tr.Proof(layerTwoKey)
+	if err != nil {
+		return nil, err
+	}
+	layerOneProof, err := tlt.layerOne.Proof(layerOneKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(proof, layerOneProof...), nil
+}
+
+// VerifyProof verifies that the value of key is stored in the trie of rootHash, given the proof returned by Proof.
+// The trie is expected to be hashed by DefaultHashFunc.
+func VerifyProof(rootHash, key, value []byte, proof [][]byte) bool {
+	expected := rootHash
+	offset := 0
+	for i := len(proof) - 1; i >= 0; i-- {
+		if !bytes.Equal(DefaultHashFunc(proof[i]), expected) {
+			return false
+		}
+		pb := triepb.NodePb{}
+		if err := proto.Unmarshal(proof[i], &pb); err != nil {
+			return false
+		}
+		switch {
+		case pb.GetBranch() != nil:
+			if offset >= len(key) {
+				return false
+			}
+			expected = nil
+			for _, b := range pb.GetBranch().GetBranches() {
+				if b.GetIndex() == uint32(key[offset]) {
+					expected = b.GetPath()
+					break
+				}
+			}
+			if expected == nil {
+				return false
+			}
+			offset++
+		case pb.GetExtend() != nil:
+			path := pb.GetExtend().GetPath()
+			if !bytes.HasPrefix(key[offset:], path) {
+				return false
+			}
+			expected = pb.GetExtend().GetValue()
+			offset += len(path)
+		case pb.GetLeaf() != nil:
+			leaf := pb.GetLeaf()
+			return i == 0 && bytes.Equal(leaf.GetPath(), key) && bytes.Equal(leaf.GetValue(), value)
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// VerifyTwoLayerProof verifies that the value of layerTwoKey is stored in the layer two trie of layerOneKey, which
+// belongs to the layer one trie of rootHash, given the proof returned by the Proof of a two layer trie
+func VerifyTwoLayerProof(rootHash, layerOneKey, layerTwoKey, value []byte, proof [][]byte) bool {
+	// the layer two proof ends at the root of layer two trie, whose hash is the value of layerOneKey in layer one
+	for i := 1; i < len(proof); i++ {
+		layerTwoRoot := DefaultHashFunc(proof[i-1])
+		if VerifyProof(rootHash, layerOneKey, layerTwoRoot, proof[i:]) {
+			return VerifyProof(layerTwoRoot, layerTwoKey, value, proof[:i])
+		}
+	}
+	return false
+}
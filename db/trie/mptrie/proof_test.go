@@ -0,0 +1,94 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db/trie"
+)
+
+func TestProof(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		require := require.New(t)
+		opts := []Option{KVStoreOption(trie.NewMemKVStore()), KeyLengthOption(8)}
+		if async {
+			opts = append(opts, AsyncOption())
+		}
+		tr, err := New(opts...)
+		require.NoError(err)
+		require.NoError(tr.Start(context.Background()))
+		_, err = tr.Proof(cat)
+		require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+		keys := [][]byte{ham, car, cat, rat, egg, dog, fox, ant}
+		for i, k := range keys {
+			require.NoError(tr.Upsert(k, testV[i%len(testV)]))
+		}
+		root, err := tr.RootHash()
+		require.NoError(err)
+		for i, k := range keys {
+			proof, err := tr.Proof(k)
+			require.NoError(err)
+			require.True(VerifyProof(root, k, testV[i%len(testV)], proof))
+			require.False(VerifyProof(root, k, []byte("wrong"), proof))
+			require.False(VerifyProof(emptyTrieRootHash, k, testV[i%len(testV)], proof))
+			require.False(VerifyProof(root, k, testV[i%len(testV)], proof[1:]))
+		}
+		// a proof doesn't prove another key
+		proof, err := tr.Proof(cat)
+		require.NoError(err)
+		require.False(VerifyProof(root, rat, testV[2], proof))
+
+		// non-existing keys
+		for _, k := range [][]byte{cow, br1, []byte{1, 2, 3, 4, 5, 6, 7, 0}} {
+			_, err = tr.Proof(k)
+			require.Equal(trie.ErrNotExist, errors.Cause(err))
+		}
+		_, err = tr.Proof([]byte{1})
+		require.Error(err)
+		require.NoError(tr.Stop(context.Background()))
+	}
+}
+
+func TestTwoLayerTrieProof(t *testing.T) {
+	require := require.New(t)
+	var (
+		ns1     = []byte("layerOneKey111111111")
+		ns2     = []byte("layerOneKey222222222")
+		ns3     = []byte("layerOneKey333333333")
+		key1    = []byte("layerTwoKey1")
+		key2    = []byte("layerTwoKey2")
+		missing = []byte("layerTwoKey3")
+	)
+	tlt := NewTwoLayerTrie(trie.NewMemKVStore(), "rootKey")
+	require.NoError(tlt.Start(context.Background()))
+	require.NoError(tlt.Upsert(ns1, key1, testV[1]))
+	require.NoError(tlt.Upsert(ns1, key2, testV[4]))
+	require.NoError(tlt.Upsert(ns2, key1, testV[2]))
+	root, err := tlt.RootHash()
+	require.NoError(err)
+
+	proof, err := tlt.Proof(ns1, key1)
+	require.NoError(err)
+	require.True(VerifyTwoLayerProof(root, ns1, key1, testV[1], proof))
+	require.False(VerifyTwoLayerProof(root, ns2, key1, testV[1], proof))
+	require.False(VerifyTwoLayerProof(root, ns1, key2, testV[1], proof))
+	require.False(VerifyTwoLayerProof(root, ns1, key1, testV[2], proof))
+	proof, err = tlt.Proof(ns2, key1)
+	require.NoError(err)
+	require.True(VerifyTwoLayerProof(root, ns2, key1, testV[2], proof))
+
+	_, err = tlt.Proof(ns2, missing)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+	_, err = tlt.Proof(ns3, key1)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+	require.NoError(tlt.Stop(context.Background()))
+}
@@ -48,6 +48,8 @@ type (
 		IsEmpty() bool
 		// Clone clones a trie with a new kvstore
 		Clone(KVStore) (Trie, error)
+		// Proof returns the serialized nodes on the path from the leaf of the key up to the root
+		Proof([]byte) ([][]byte, error)
 	}
 	// TwoLayerTrie is a trie data structure with two layers
 	TwoLayerTrie interface {
@@ -65,5 +67,7 @@ type (
 		Upsert([]byte, []byte, []byte) error
 		// Delete deletes an item in layer two
 		Delete([]byte, []byte) error
+		// Proof returns the proof of an item in layer two, which is the layer two proof followed by the layer one proof
+		Proof([]byte, []byte) ([][]byte, error)
 	}
 )
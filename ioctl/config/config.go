@@ -110,7 +110,7 @@ func init() {
 		completeness = false
 	}
 	if ReadConfig.Nsv2height == 0 {
-		ReadConfig.Nsv2height = genesis.Default.FairbankBlockHeight
+		ReadConfig.Nsv2height = genesis.GetDefault().FairbankBlockHeight
 	}
 	if ReadConfig.AnalyserEndpoint == "" {
 		ReadConfig.AnalyserEndpoint = _defaultAnalyserEndpoint
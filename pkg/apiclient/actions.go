@@ -0,0 +1,96 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+)
+
+// MaxActionsPerRequest is the max number of actions requested by one GetActions call, which is the default range
+// query limit of the api server
+const MaxActionsPerRequest uint64 = 1000
+
+// ActionsByBlock returns at most count actions of the block of blkHash starting from the index start, in the order of
+// the actions in the block
+func ActionsByBlock(ctx context.Context, c iotexapi.APIServiceClient, blkHash string, start, count uint64) ([]*iotexapi.ActionInfo, error) {
+	if err := validateBlockHash(blkHash); err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.New("count must be greater than zero")
+	}
+	res, err := c.GetActions(ctx, &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_ByBlk{
+			ByBlk: &iotexapi.GetActionsByBlockRequest{BlkHash: blkHash, Start: start, Count: count},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get actions of block %s from index %d", blkHash, start)
+	}
+	infos := res.GetActionInfo()
+	for i, info := range infos {
+		if info.GetIndex() < uint32(start) || (i > 0 && info.GetIndex() <= infos[i-1].GetIndex()) {
+			return nil, errors.Errorf("action %s at index %d of block %s is out of order", info.GetActHash(), info.GetIndex(), blkHash)
+		}
+	}
+	return infos, nil
+}
+
+// ActionsByBlockAll calls fn on each action of the block of blkHash in the order of the actions in the block. The
+// number of actions is read from the block meta, and the actions are requested in pages of MaxActionsPerRequest. It
+// stops at the first error returned by fn.
+func ActionsByBlockAll(ctx context.Context, c iotexapi.APIServiceClient, blkHash string, fn func(*iotexapi.ActionInfo) error) error {
+	if err := validateBlockHash(blkHash); err != nil {
+		return err
+	}
+	res, err := c.GetBlockMetas(ctx, &iotexapi.GetBlockMetasRequest{
+		Lookup: &iotexapi.GetBlockMetasRequest_ByHash{
+			ByHash: &iotexapi.GetBlockMetaByHashRequest{BlkHash: blkHash},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get block meta of %s", blkHash)
+	}
+	if len(res.GetBlkMetas()) != 1 {
+		return errors.Errorf("expect 1 block meta of %s, got %d", blkHash, len(res.GetBlkMetas()))
+	}
+	numActions := uint64(res.GetBlkMetas()[0].GetNumActions())
+	for start := uint64(0); start < numActions; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		infos, err := ActionsByBlock(ctx, c, blkHash, start, MaxActionsPerRequest)
+		if err != nil {
+			return err
+		}
+		if len(infos) == 0 {
+			break
+		}
+		for _, info := range infos {
+			if err := fn(info); err != nil {
+				return err
+			}
+		}
+		start = uint64(infos[len(infos)-1].GetIndex()) + 1
+	}
+	return nil
+}
+
+func validateBlockHash(blkHash string) error {
+	b, err := hex.DecodeString(blkHash)
+	if err != nil {
+		return errors.Wrapf(err, "invalid block hash %s", blkHash)
+	}
+	if len(b) != len(hash.ZeroHash256) {
+		return errors.Errorf("invalid block hash %s of %d bytes, expecting %d", blkHash, len(b), len(hash.ZeroHash256))
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestActionsByBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	h := hash.Hash256b([]byte("block"))
+	blkHash := hex.EncodeToString(h[:])
+	const numActions = 2500
+	getActions := func(_ context.Context, in *iotexapi.GetActionsRequest, _ ...grpc.CallOption) (*iotexapi.GetActionsResponse, error) {
+		req := in.GetByBlk()
+		require.Equal(blkHash, req.GetBlkHash())
+		require.LessOrEqual(req.GetCount(), MaxActionsPerRequest)
+		infos := []*iotexapi.ActionInfo{}
+		for i := req.GetStart(); i < req.GetStart()+req.GetCount() && i < numActions; i++ {
+			infos = append(infos, &iotexapi.ActionInfo{ActHash: fmt.Sprintf("act%d", i), BlkHash: blkHash, Index: uint32(i)})
+		}
+		return &iotexapi.GetActionsResponse{Total: uint64(len(infos)), ActionInfo: infos}, nil
+	}
+
+	c.EXPECT().GetActions(gomock.Any(), gomock.Any()).DoAndReturn(getActions).Times(1)
+	infos, err := ActionsByBlock(ctx, c, blkHash, 10, 5)
+	require.NoError(err)
+	require.Len(infos, 5)
+	for i, info := range infos {
+		require.EqualValues(10+i, info.GetIndex())
+	}
+
+	// all actions in pages
+	c.EXPECT().GetBlockMetas(gomock.Any(), gomock.Any()).Return(&iotexapi.GetBlockMetasResponse{
+		BlkMetas: []*iotextypes.BlockMeta{{Hash: blkHash, NumActions: numActions}},
+	}, nil).Times(2)
+	c.EXPECT().GetActions(gomock.Any(), gomock.Any()).DoAndReturn(getActions).Times(4)
+	var hashes []string
+	require.NoError(ActionsByBlockAll(ctx, c, blkHash, func(info *iotexapi.ActionInfo) error {
+		hashes = append(hashes, info.GetActHash())
+		return nil
+	}))
+	require.Len(hashes, numActions)
+	require.Equal("act0", hashes[0])
+	require.Equal("act2499", hashes[numActions-1])
+
+	// stop at the error of fn
+	errStop := errors.New("stop")
+	n := 0
+	require.Equal(errStop, ActionsByBlockAll(ctx, c, blkHash, func(info *iotexapi.ActionInfo) error {
+		if n++; n == 10 {
+			return errStop
+		}
+		return nil
+	}))
+	require.Equal(10, n)
+
+	// invalid arguments
+	for _, h := range []string{"", "xyz", blkHash[2:], blkHash + "00"} {
+		_, err = ActionsByBlock(ctx, c, h, 0, 1)
+		require.ErrorContains(err, "invalid block hash", h)
+		require.ErrorContains(ActionsByBlockAll(ctx, c, h, nil), "invalid block hash", h)
+	}
+	_, err = ActionsByBlock(ctx, c, blkHash, 0, 0)
+	require.ErrorContains(err, "count must be greater than zero")
+
+	// actions out of order
+	c.EXPECT().GetActions(gomock.Any(), gomock.Any()).Return(&iotexapi.GetActionsResponse{
+		ActionInfo: []*iotexapi.ActionInfo{{ActHash: "act1", Index: 1}, {ActHash: "act0", Index: 0}},
+	}, nil)
+	_, err = ActionsByBlock(ctx, c, blkHash, 0, 2)
+	require.ErrorContains(err, "action act0 at index 0 of block "+blkHash+" is out of order")
+
+	c.EXPECT().GetActions(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, err = ActionsByBlock(ctx, c, blkHash, 0, 2)
+	require.ErrorContains(err, "unavailable")
+}
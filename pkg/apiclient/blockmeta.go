@@ -0,0 +1,52 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+)
+
+// MaxBlockMetasPerRequest is the max number of block metas requested by one GetBlockMetas call, which is the default
+// range query limit of the api server
+const MaxBlockMetasPerRequest uint64 = 1000
+
+// BlockMetasRange returns the metas of at most count blocks starting from height start in height order. The range is
+// requested in chunks of MaxBlockMetasPerRequest blocks, and fewer metas are returned if the range goes beyond the tip.
+func BlockMetasRange(ctx context.Context, c iotexapi.APIServiceClient, start, count uint64) ([]*iotextypes.BlockMeta, error) {
+	if count == 0 {
+		return nil, errors.New("count must be greater than zero")
+	}
+	metas := make([]*iotextypes.BlockMeta, 0, count)
+	for count > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		n := count
+		if n > MaxBlockMetasPerRequest {
+			n = MaxBlockMetasPerRequest
+		}
+		res, err := c.GetBlockMetas(ctx, &iotexapi.GetBlockMetasRequest{
+			Lookup: &iotexapi.GetBlockMetasRequest_ByIndex{
+				ByIndex: &iotexapi.GetBlockMetasByIndexRequest{Start: start, Count: n},
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get block metas from height %d", start)
+		}
+		metas = append(metas, res.GetBlkMetas()...)
+		if uint64(len(res.GetBlkMetas())) < n {
+			// reached the tip
+			break
+		}
+		start += n
+		count -= n
+	}
+	return metas, nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestBlockMetasRange(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	const tip = 2500
+	c.EXPECT().GetBlockMetas(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.GetBlockMetasRequest, _ ...grpc.CallOption) (*iotexapi.GetBlockMetasResponse, error) {
+			req := in.GetByIndex()
+			require.LessOrEqual(req.GetCount(), MaxBlockMetasPerRequest)
+			metas := []*iotextypes.BlockMeta{}
+			for h := req.GetStart(); h < req.GetStart()+req.GetCount() && h <= tip; h++ {
+				metas = append(metas, &iotextypes.BlockMeta{Height: h})
+			}
+			return &iotexapi.GetBlockMetasResponse{Total: uint64(len(metas)), BlkMetas: metas}, nil
+		}).Times(4)
+
+	for _, v := range []struct {
+		start, count, expected uint64
+	}{
+		{1, 10, 10},
+		{1, 2000, 2000},
+		{2000, 1000, 501},
+	} {
+		metas, err := BlockMetasRange(ctx, c, v.start, v.count)
+		require.NoError(err)
+		require.Len(metas, int(v.expected))
+		for i, meta := range metas {
+			require.Equal(v.start+uint64(i), meta.GetHeight())
+		}
+	}
+
+	_, err := BlockMetasRange(ctx, c, 1, 0)
+	require.Error(err)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = BlockMetasRange(ctx, c, 1, 10)
+	require.Equal(context.Canceled, err)
+}
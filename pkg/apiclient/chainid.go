@@ -0,0 +1,72 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+)
+
+// ChainIDClient is a ServiceClient which caches the chain ID of the network it connects to
+type ChainIDClient struct {
+	ServiceClient
+	mutex   sync.Mutex
+	chainID uint32
+}
+
+// ChainID returns the chain ID of the network read from the chain meta
+func ChainID(ctx context.Context, c iotexapi.APIServiceClient) (uint32, error) {
+	res, err := c.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return 0, err
+	}
+	chainID := res.GetChainMeta().GetChainID()
+	if chainID == 0 {
+		return 0, errors.New("chain ID is not available in chain meta")
+	}
+	return chainID, nil
+}
+
+// NewChainIDClient creates a ChainIDClient
+func NewChainIDClient(c ServiceClient) *ChainIDClient {
+	return &ChainIDClient{ServiceClient: c}
+}
+
+// ChainID returns the chain ID of the network, which is read upon the first successful call and cached afterwards
+func (c *ChainIDClient) ChainID(ctx context.Context) (uint32, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.chainID != 0 {
+		return c.chainID, nil
+	}
+	chainID, err := ChainID(ctx, c.ServiceClient)
+	if err != nil {
+		return 0, err
+	}
+	c.chainID = chainID
+	return chainID, nil
+}
+
+// StampChainID sets the chain ID of the action core to the one of the network if it is not set, and returns an error
+// if it is set to a different one
+func (c *ChainIDClient) StampChainID(ctx context.Context, core *iotextypes.ActionCore) error {
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	switch core.GetChainID() {
+	case 0:
+		core.ChainID = chainID
+	case chainID:
+	default:
+		return errors.Errorf("action chain ID %d doesn't match network chain ID %d", core.GetChainID(), chainID)
+	}
+	return nil
+}
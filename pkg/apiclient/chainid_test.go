@@ -0,0 +1,55 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainID(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+
+	c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+		ChainMeta: &iotextypes.ChainMeta{},
+	}, nil)
+	_, err := ChainID(ctx, c)
+	require.Error(err)
+
+	client := NewChainIDClient(c)
+	c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, err = client.ChainID(ctx)
+	require.Error(err)
+
+	// chain ID is read only once
+	c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+		ChainMeta: &iotextypes.ChainMeta{ChainID: 4689},
+	}, nil).Times(1)
+	for i := 0; i < 3; i++ {
+		chainID, err := client.ChainID(ctx)
+		require.NoError(err)
+		require.EqualValues(4689, chainID)
+	}
+
+	core := &iotextypes.ActionCore{}
+	require.NoError(client.StampChainID(ctx, core))
+	require.EqualValues(4689, core.ChainID)
+	require.NoError(client.StampChainID(ctx, core))
+	core.ChainID = 4690
+	require.Error(client.StampChainID(ctx, core))
+}
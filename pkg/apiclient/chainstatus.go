@@ -0,0 +1,31 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+)
+
+// ChainStatus returns the tip height, the current epoch number and the TPS of the chain read from the chain meta. The
+// TPS is the tpsFloat of the chain meta, or the integer tps if the server doesn't fill the tpsFloat.
+func ChainStatus(ctx context.Context, c iotexapi.APIServiceClient) (tipHeight, epoch uint64, tps float64, err error) {
+	res, err := c.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return 0, 0, 0, errors.Wrap(err, "failed to get chain meta")
+	}
+	meta := res.GetChainMeta()
+	if meta == nil {
+		return 0, 0, 0, errors.New("chain meta is not available")
+	}
+	tps = float64(meta.GetTpsFloat())
+	if tps == 0 {
+		tps = float64(meta.GetTps())
+	}
+	return meta.GetHeight(), meta.GetEpoch().GetNum(), tps, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainStatus(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	for _, v := range []struct {
+		tps      int64
+		tpsFloat float32
+		expected float64
+	}{
+		{12, 12.5, 12.5},
+		{12, 0, 12},
+		{0, 0, 0},
+	} {
+		c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+			ChainMeta: &iotextypes.ChainMeta{
+				Height:   24000001,
+				Epoch:    &iotextypes.EpochData{Num: 33334},
+				Tps:      v.tps,
+				TpsFloat: v.tpsFloat,
+			},
+		}, nil)
+		height, epoch, tps, err := ChainStatus(ctx, c)
+		require.NoError(err)
+		require.EqualValues(24000001, height)
+		require.EqualValues(33334, epoch)
+		require.Equal(v.expected, tps)
+	}
+
+	c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{}, nil)
+	_, _, _, err := ChainStatus(ctx, c)
+	require.ErrorContains(err, "chain meta is not available")
+
+	c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, _, _, err = ChainStatus(ctx, c)
+	require.ErrorContains(err, "unavailable")
+}
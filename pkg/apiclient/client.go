@@ -0,0 +1,201 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type (
+	// ServiceClient is the client of IoTeX API service
+	ServiceClient = iotexapi.APIServiceClient
+
+	decoratedClient struct {
+		inner   ServiceClient
+		md      metadata.MD
+		timeout time.Duration
+	}
+)
+
+// NewDecoratedClient returns a client which attaches md to the outgoing context of every call and applies timeout to
+// every unary call. A zero timeout leaves the caller's deadline untouched. Streaming calls only carry the metadata,
+// since a per-call timeout would tear down a long-lived stream.
+func NewDecoratedClient(inner ServiceClient, md metadata.MD, timeout time.Duration) ServiceClient {
+	return &decoratedClient{
+		inner:   inner,
+		md:      md.Copy(),
+		timeout: timeout,
+	}
+}
+
+func (c *decoratedClient) withMetadata(ctx context.Context) context.Context {
+	if len(c.md) == 0 {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		return metadata.NewOutgoingContext(ctx, metadata.Join(md, c.md))
+	}
+	return metadata.NewOutgoingContext(ctx, c.md)
+}
+
+func (c *decoratedClient) unaryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = c.withMetadata(ctx)
+	if c.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+func (c *decoratedClient) GetAccount(ctx context.Context, in *iotexapi.GetAccountRequest, opts ...grpc.CallOption) (*iotexapi.GetAccountResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetAccount(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetActions(ctx context.Context, in *iotexapi.GetActionsRequest, opts ...grpc.CallOption) (*iotexapi.GetActionsResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetActions(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetBlockMetas(ctx context.Context, in *iotexapi.GetBlockMetasRequest, opts ...grpc.CallOption) (*iotexapi.GetBlockMetasResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetBlockMetas(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetChainMeta(ctx context.Context, in *iotexapi.GetChainMetaRequest, opts ...grpc.CallOption) (*iotexapi.GetChainMetaResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetChainMeta(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetServerMeta(ctx context.Context, in *iotexapi.GetServerMetaRequest, opts ...grpc.CallOption) (*iotexapi.GetServerMetaResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetServerMeta(ctx, in, opts...)
+}
+
+func (c *decoratedClient) SendAction(ctx context.Context, in *iotexapi.SendActionRequest, opts ...grpc.CallOption) (*iotexapi.SendActionResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.SendAction(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetReceiptByAction(ctx context.Context, in *iotexapi.GetReceiptByActionRequest, opts ...grpc.CallOption) (*iotexapi.GetReceiptByActionResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetReceiptByAction(ctx, in, opts...)
+}
+
+func (c *decoratedClient) ReadContract(ctx context.Context, in *iotexapi.ReadContractRequest, opts ...grpc.CallOption) (*iotexapi.ReadContractResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.ReadContract(ctx, in, opts...)
+}
+
+func (c *decoratedClient) SuggestGasPrice(ctx context.Context, in *iotexapi.SuggestGasPriceRequest, opts ...grpc.CallOption) (*iotexapi.SuggestGasPriceResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.SuggestGasPrice(ctx, in, opts...)
+}
+
+func (c *decoratedClient) EstimateGasForAction(ctx context.Context, in *iotexapi.EstimateGasForActionRequest, opts ...grpc.CallOption) (*iotexapi.EstimateGasForActionResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.EstimateGasForAction(ctx, in, opts...)
+}
+
+func (c *decoratedClient) EstimateActionGasConsumption(ctx context.Context, in *iotexapi.EstimateActionGasConsumptionRequest, opts ...grpc.CallOption) (*iotexapi.EstimateActionGasConsumptionResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.EstimateActionGasConsumption(ctx, in, opts...)
+}
+
+func (c *decoratedClient) ReadState(ctx context.Context, in *iotexapi.ReadStateRequest, opts ...grpc.CallOption) (*iotexapi.ReadStateResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.ReadState(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetEpochMeta(ctx context.Context, in *iotexapi.GetEpochMetaRequest, opts ...grpc.CallOption) (*iotexapi.GetEpochMetaResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetEpochMeta(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetRawBlocks(ctx context.Context, in *iotexapi.GetRawBlocksRequest, opts ...grpc.CallOption) (*iotexapi.GetRawBlocksResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetRawBlocks(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetLogs(ctx context.Context, in *iotexapi.GetLogsRequest, opts ...grpc.CallOption) (*iotexapi.GetLogsResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetLogs(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetTransactionLogByActionHash(ctx context.Context, in *iotexapi.GetTransactionLogByActionHashRequest, opts ...grpc.CallOption) (*iotexapi.GetTransactionLogByActionHashResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetTransactionLogByActionHash(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetTransactionLogByBlockHeight(ctx context.Context, in *iotexapi.GetTransactionLogByBlockHeightRequest, opts ...grpc.CallOption) (*iotexapi.GetTransactionLogByBlockHeightResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetTransactionLogByBlockHeight(ctx, in, opts...)
+}
+
+func (c *decoratedClient) StreamBlocks(ctx context.Context, in *iotexapi.StreamBlocksRequest, opts ...grpc.CallOption) (iotexapi.APIService_StreamBlocksClient, error) {
+	return c.inner.StreamBlocks(c.withMetadata(ctx), in, opts...)
+}
+
+func (c *decoratedClient) StreamLogs(ctx context.Context, in *iotexapi.StreamLogsRequest, opts ...grpc.CallOption) (iotexapi.APIService_StreamLogsClient, error) {
+	return c.inner.StreamLogs(c.withMetadata(ctx), in, opts...)
+}
+
+func (c *decoratedClient) GetActPoolActions(ctx context.Context, in *iotexapi.GetActPoolActionsRequest, opts ...grpc.CallOption) (*iotexapi.GetActPoolActionsResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetActPoolActions(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetEvmTransfersByActionHash(ctx context.Context, in *iotexapi.GetEvmTransfersByActionHashRequest, opts ...grpc.CallOption) (*iotexapi.GetEvmTransfersByActionHashResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetEvmTransfersByActionHash(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetEvmTransfersByBlockHeight(ctx context.Context, in *iotexapi.GetEvmTransfersByBlockHeightRequest, opts ...grpc.CallOption) (*iotexapi.GetEvmTransfersByBlockHeightResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetEvmTransfersByBlockHeight(ctx, in, opts...)
+}
+
+func (c *decoratedClient) GetElectionBuckets(ctx context.Context, in *iotexapi.GetElectionBucketsRequest, opts ...grpc.CallOption) (*iotexapi.GetElectionBucketsResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.GetElectionBuckets(ctx, in, opts...)
+}
+
+func (c *decoratedClient) ReadContractStorage(ctx context.Context, in *iotexapi.ReadContractStorageRequest, opts ...grpc.CallOption) (*iotexapi.ReadContractStorageResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.ReadContractStorage(ctx, in, opts...)
+}
+
+func (c *decoratedClient) TraceTransactionStructLogs(ctx context.Context, in *iotexapi.TraceTransactionStructLogsRequest, opts ...grpc.CallOption) (*iotexapi.TraceTransactionStructLogsResponse, error) {
+	ctx, cancel := c.unaryContext(ctx)
+	defer cancel()
+	return c.inner.TraceTransactionStructLogs(ctx, in, opts...)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestDecoratedClient(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inner := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	md := metadata.Pairs("authorization", "bearer token")
+	c := NewDecoratedClient(inner, md, time.Minute)
+
+	t.Run("metadata and deadline are attached", func(t *testing.T) {
+		inner.EXPECT().GetAccount(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, in *iotexapi.GetAccountRequest, opts ...grpc.CallOption) (*iotexapi.GetAccountResponse, error) {
+				out, ok := metadata.FromOutgoingContext(ctx)
+				require.True(ok)
+				require.Equal([]string{"bearer token"}, out.Get("authorization"))
+				deadline, ok := ctx.Deadline()
+				require.True(ok)
+				require.WithinDuration(time.Now().Add(time.Minute), deadline, 5*time.Second)
+				return &iotexapi.GetAccountResponse{}, nil
+			})
+		_, err := c.GetAccount(context.Background(), &iotexapi.GetAccountRequest{})
+		require.NoError(err)
+	})
+
+	t.Run("existing metadata is preserved", func(t *testing.T) {
+		inner.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, in *iotexapi.GetChainMetaRequest, opts ...grpc.CallOption) (*iotexapi.GetChainMetaResponse, error) {
+				out, ok := metadata.FromOutgoingContext(ctx)
+				require.True(ok)
+				require.Equal([]string{"bearer token"}, out.Get("authorization"))
+				require.Equal([]string{"abc"}, out.Get("x-request-id"))
+				return &iotexapi.GetChainMetaResponse{}, nil
+			})
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "abc")
+		_, err := c.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+		require.NoError(err)
+	})
+
+	t.Run("zero timeout keeps caller deadline", func(t *testing.T) {
+		c := NewDecoratedClient(inner, md, 0)
+		inner.EXPECT().SuggestGasPrice(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, in *iotexapi.SuggestGasPriceRequest, opts ...grpc.CallOption) (*iotexapi.SuggestGasPriceResponse, error) {
+				_, ok := ctx.Deadline()
+				require.False(ok)
+				return &iotexapi.SuggestGasPriceResponse{}, nil
+			})
+		_, err := c.SuggestGasPrice(context.Background(), &iotexapi.SuggestGasPriceRequest{})
+		require.NoError(err)
+	})
+}
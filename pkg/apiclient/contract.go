@@ -0,0 +1,51 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+)
+
+// CallContract packs the call of method with args according to abiJSON, reads the contract, and unpacks the returned
+// data according to the outputs of method
+func CallContract(ctx context.Context, c ServiceClient, contract string, abiJSON string, method string, args ...interface{}) ([]interface{}, error) {
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse abi")
+	}
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to pack method %s", method)
+	}
+	res, err := c.ReadContract(ctx, &iotexapi.ReadContractRequest{
+		Execution: &iotextypes.Execution{
+			Amount:   "0",
+			Contract: contract,
+			Data:     data,
+		},
+		CallerAddress: address.ZeroAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret, err := hex.DecodeString(res.GetData())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode returned data")
+	}
+	values, err := contractABI.Unpack(method, ret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unpack the result of method %s", method)
+	}
+	return values, nil
+}
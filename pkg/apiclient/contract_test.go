@@ -0,0 +1,59 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+const _balanceOfABI = `[{"constant":true,"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"}]`
+
+func TestCallContract(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	contract := identityset.Address(1).String()
+	owner := common.BytesToAddress(identityset.Address(2).Bytes())
+
+	c.EXPECT().ReadContract(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.ReadContractRequest, _ ...grpc.CallOption) (*iotexapi.ReadContractResponse, error) {
+			require.Equal(contract, in.GetExecution().GetContract())
+			// 4-byte selector of balanceOf(address) followed by the padded owner
+			require.Equal("70a08231", hex.EncodeToString(in.GetExecution().GetData()[:4]))
+			require.Equal(owner.Bytes(), in.GetExecution().GetData()[16:36])
+			return &iotexapi.ReadContractResponse{
+				Data: hex.EncodeToString(common.LeftPadBytes(big.NewInt(100).Bytes(), 32)),
+			}, nil
+		})
+	values, err := CallContract(ctx, c, contract, _balanceOfABI, "balanceOf", owner)
+	require.NoError(err)
+	require.Len(values, 1)
+	require.Equal(big.NewInt(100), values[0])
+
+	_, err = CallContract(ctx, c, contract, _balanceOfABI, "transfer", owner)
+	require.Error(err)
+	_, err = CallContract(ctx, c, contract, "invalid abi", "balanceOf", owner)
+	require.Error(err)
+
+	c.EXPECT().ReadContract(gomock.Any(), gomock.Any()).Return(&iotexapi.ReadContractResponse{Data: "xyz"}, nil)
+	_, err = CallContract(ctx, c, contract, _balanceOfABI, "balanceOf", owner)
+	require.Error(err)
+}
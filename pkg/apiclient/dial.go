@@ -0,0 +1,129 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// DefaultMaxRecvMsgSize is the default max size of a message received by the client, which is raised from the
+	// 4MB default of grpc, since a response of blocks could be large
+	DefaultMaxRecvMsgSize = 64 << 20
+	// DefaultCallTimeout is the default timeout of a unary call whose context has no deadline
+	DefaultCallTimeout = 30 * time.Second
+)
+
+type (
+	// ClientOption sets an option of the connection created by NewServiceClient
+	ClientOption func(*clientConfig)
+
+	clientConfig struct {
+		tlsConfig      *tls.Config
+		keepalive      *keepalive.ClientParameters
+		maxRecvMsgSize int
+		timeout        time.Duration
+		dialOpts       []grpc.DialOption
+	}
+)
+
+// WithTLS enables TLS on the connection. A nil config uses TLS 1.2 at least, the same as ioctl does.
+func WithTLS(cfg *tls.Config) ClientOption {
+	return func(c *clientConfig) {
+		if cfg == nil {
+			cfg = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		c.tlsConfig = cfg
+	}
+}
+
+// WithKeepalive enables keepalive pings on the connection. Note that the api server closes the connection if it is
+// pinged more than once per second.
+func WithKeepalive(params keepalive.ClientParameters) ClientOption {
+	return func(c *clientConfig) {
+		c.keepalive = &params
+	}
+}
+
+// WithMaxRecvMsgSize sets the max size of a message received by the client
+func WithMaxRecvMsgSize(size int) ClientOption {
+	return func(c *clientConfig) {
+		c.maxRecvMsgSize = size
+	}
+}
+
+// WithCallTimeout sets the timeout of a unary call whose context has no deadline, zero disables the timeout
+func WithCallTimeout(timeout time.Duration) ClientOption {
+	return func(c *clientConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithDialOptions appends extra grpc dial options
+func WithDialOptions(opts ...grpc.DialOption) ClientOption {
+	return func(c *clientConfig) {
+		c.dialOpts = append(c.dialOpts, opts...)
+	}
+}
+
+// NewServiceClient creates a client connected to the api service at target, and the closer of the connection. The
+// connection is established lazily, so an unreachable target fails the calls rather than NewServiceClient.
+func NewServiceClient(target string, opts ...ClientOption) (iotexapi.APIServiceClient, io.Closer, error) {
+	cfg := clientConfig{
+		maxRecvMsgSize: DefaultMaxRecvMsgSize,
+		timeout:        DefaultCallTimeout,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.maxRecvMsgSize <= 0 {
+		return nil, nil, errors.Errorf("invalid max receive message size %d", cfg.maxRecvMsgSize)
+	}
+	if cfg.timeout < 0 {
+		return nil, nil, errors.Errorf("invalid call timeout %s", cfg.timeout)
+	}
+	dialOpts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(cfg.maxRecvMsgSize)),
+	}
+	if cfg.tlsConfig != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(cfg.tlsConfig)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if cfg.keepalive != nil {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(*cfg.keepalive))
+	}
+	if cfg.timeout > 0 {
+		dialOpts = append(dialOpts, grpc.WithUnaryInterceptor(timeoutInterceptor(cfg.timeout)))
+	}
+	conn, err := grpc.Dial(target, append(dialOpts, cfg.dialOpts...)...)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to dial %s", target)
+	}
+	return iotexapi.NewAPIServiceClient(conn), conn, nil
+}
+
+// timeoutInterceptor applies timeout to the unary calls whose context has no deadline
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type testAPIServer struct {
+	iotexapi.UnimplementedAPIServiceServer
+	deadlines chan time.Duration
+}
+
+func (s *testAPIServer) GetChainMeta(ctx context.Context, _ *iotexapi.GetChainMetaRequest) (*iotexapi.GetChainMetaResponse, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		s.deadlines <- 0
+	} else {
+		s.deadlines <- time.Until(deadline)
+	}
+	return &iotexapi.GetChainMetaResponse{ChainMeta: &iotextypes.ChainMeta{Height: 100}}, nil
+}
+
+func (s *testAPIServer) ReadContractStorage(context.Context, *iotexapi.ReadContractStorageRequest) (*iotexapi.ReadContractStorageResponse, error) {
+	// larger than the 4MB default limit of grpc
+	return &iotexapi.ReadContractStorageResponse{Data: make([]byte, 5<<20)}, nil
+}
+
+func TestNewServiceClient(t *testing.T) {
+	require := require.New(t)
+	lis := bufconn.Listen(1 << 20)
+	svr := grpc.NewServer()
+	api := &testAPIServer{deadlines: make(chan time.Duration, 1)}
+	iotexapi.RegisterAPIServiceServer(svr, api)
+	go svr.Serve(lis)
+	defer svr.Stop()
+	dialer := WithDialOptions(grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	ctx := context.Background()
+
+	t.Run("default options", func(t *testing.T) {
+		c, closer, err := NewServiceClient("bufnet", dialer)
+		require.NoError(err)
+		defer closer.Close()
+
+		res, err := c.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+		require.NoError(err)
+		require.EqualValues(100, res.GetChainMeta().GetHeight())
+		timeout := <-api.deadlines
+		require.True(timeout > 0 && timeout <= DefaultCallTimeout)
+
+		// the deadline of the caller is kept
+		callCtx, cancel := context.WithTimeout(ctx, time.Hour)
+		defer cancel()
+		_, err = c.GetChainMeta(callCtx, &iotexapi.GetChainMetaRequest{})
+		require.NoError(err)
+		require.True(<-api.deadlines > DefaultCallTimeout)
+
+		storage, err := c.ReadContractStorage(ctx, &iotexapi.ReadContractStorageRequest{})
+		require.NoError(err)
+		require.Len(storage.GetData(), 5<<20)
+	})
+
+	t.Run("custom options", func(t *testing.T) {
+		c, closer, err := NewServiceClient("bufnet", dialer, WithCallTimeout(0), WithMaxRecvMsgSize(4<<20))
+		require.NoError(err)
+		defer closer.Close()
+
+		_, err = c.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+		require.NoError(err)
+		require.Zero(<-api.deadlines)
+
+		_, err = c.ReadContractStorage(ctx, &iotexapi.ReadContractStorageRequest{})
+		require.Equal(codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, _, err := NewServiceClient("bufnet", WithMaxRecvMsgSize(0))
+		require.Error(err)
+		_, _, err = NewServiceClient("bufnet", WithCallTimeout(-time.Second))
+		require.Error(err)
+	})
+}
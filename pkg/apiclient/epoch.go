@@ -0,0 +1,42 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+)
+
+// ProducerStat is the block production of a block producer in an epoch
+type ProducerStat struct {
+	Address    string
+	Production uint64
+	Active     bool
+}
+
+// EpochProducers returns the block producers of the epoch and the number of blocks each of them produced, in the
+// order returned by GetEpochMeta. An inactive producer, i.e., one which is probated in the epoch, is included with
+// Active false.
+func EpochProducers(ctx context.Context, c iotexapi.APIServiceClient, epoch uint64) ([]ProducerStat, error) {
+	if epoch == 0 {
+		return nil, errors.New("epoch number cannot be zero")
+	}
+	res, err := c.GetEpochMeta(ctx, &iotexapi.GetEpochMetaRequest{EpochNumber: epoch})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get meta of epoch %d", epoch)
+	}
+	stats := make([]ProducerStat, 0, len(res.GetBlockProducersInfo()))
+	for _, info := range res.GetBlockProducersInfo() {
+		stats = append(stats, ProducerStat{
+			Address:    info.GetAddress(),
+			Production: info.GetProduction(),
+			Active:     info.GetActive(),
+		})
+	}
+	return stats, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestEpochProducers(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	c.EXPECT().GetEpochMeta(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.GetEpochMetaRequest, _ ...grpc.CallOption) (*iotexapi.GetEpochMetaResponse, error) {
+			require.EqualValues(10, in.GetEpochNumber())
+			return &iotexapi.GetEpochMetaResponse{
+				EpochData:   &iotextypes.EpochData{Num: 10, Height: 7201},
+				TotalBlocks: 720,
+				BlockProducersInfo: []*iotexapi.BlockProducerInfo{
+					{Address: identityset.Address(1).String(), Votes: "100", Active: true, Production: 400},
+					{Address: identityset.Address(2).String(), Votes: "90", Active: true, Production: 320},
+					{Address: identityset.Address(3).String(), Votes: "80", Active: false, Production: 0},
+				},
+			}, nil
+		})
+	stats, err := EpochProducers(ctx, c, 10)
+	require.NoError(err)
+	require.Equal([]ProducerStat{
+		{Address: identityset.Address(1).String(), Production: 400, Active: true},
+		{Address: identityset.Address(2).String(), Production: 320, Active: true},
+		{Address: identityset.Address(3).String(), Production: 0, Active: false},
+	}, stats)
+
+	c.EXPECT().GetEpochMeta(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, err = EpochProducers(ctx, c, 10)
+	require.Error(err)
+
+	_, err = EpochProducers(ctx, c, 0)
+	require.Error(err)
+}
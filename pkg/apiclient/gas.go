@@ -0,0 +1,147 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+)
+
+// EstimateGas estimates the gas consumed by the action.
+//
+// Transfers, executions, staking and candidate actions are estimated by EstimateActionGasConsumption, which doesn't
+// need the action to be signed and searches for the lowest gas limit an execution succeeds with. The caller of an
+// execution is derived from the sender public key, so it must be set. Any other action type is estimated by
+// EstimateGasForAction, which requires a sealed action.
+func EstimateGas(ctx context.Context, c ServiceClient, act *iotextypes.Action) (uint64, error) {
+	if act.GetCore() == nil {
+		return 0, errors.New("action core is nil")
+	}
+	req := estimateActionGasConsumptionRequest(act.GetCore())
+	if req == nil {
+		res, err := c.EstimateGasForAction(ctx, &iotexapi.EstimateGasForActionRequest{Action: act})
+		if err != nil {
+			return 0, err
+		}
+		return res.GetGas(), nil
+	}
+	if len(act.GetSenderPubKey()) > 0 {
+		pk, err := crypto.BytesToPublicKey(act.GetSenderPubKey())
+		if err != nil {
+			return 0, errors.Wrap(err, "invalid sender public key")
+		}
+		req.CallerAddress = pk.Address().String()
+	}
+	if req.GetExecution() != nil && req.CallerAddress == "" {
+		return 0, errors.New("sender public key is required to estimate an execution")
+	}
+	res, err := c.EstimateActionGasConsumption(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return res.GetGas(), nil
+}
+
+// EstimateGasWithinLimits estimates the gas consumed by the action as EstimateGas does, and returns an error if the
+// estimation exceeds the action gas limit, or a block could not hold it under the block gas limit
+func EstimateGasWithinLimits(ctx context.Context, c ServiceClient, act *iotextypes.Action, bc *genesis.Blockchain) (uint64, error) {
+	gas, err := EstimateGas(ctx, c, act)
+	if err != nil {
+		return 0, err
+	}
+	if err := bc.CheckActionGas(gas); err != nil {
+		return 0, err
+	}
+	if err := bc.CheckBlockGas(gas); err != nil {
+		return 0, err
+	}
+	return gas, nil
+}
+
+// EstimateGasWithBuffer estimates the gas consumed by the action as EstimateGas does, and raises it by bufferPct
+// percent, i.e., gas * (100 + bufferPct) / 100 rounded up, as an estimation could come back slightly low, and the
+// action would then run out of gas on chain. A buffer of 10 to 20 percent is common. The result is clamped to the
+// action gas limit of bc, the blockchain genesis of the network the action is sent to, while an estimation exceeding
+// the limit itself is an error.
+func EstimateGasWithBuffer(ctx context.Context, c ServiceClient, act *iotextypes.Action, bc *genesis.Blockchain, bufferPct int) (uint64, error) {
+	if bufferPct < 0 {
+		return 0, errors.Errorf("invalid gas buffer percent %d", bufferPct)
+	}
+	gas, err := EstimateGas(ctx, c, act)
+	if err != nil {
+		return 0, err
+	}
+	if err := bc.CheckActionGas(gas); err != nil {
+		return 0, err
+	}
+	buffered := new(big.Int).SetUint64(gas)
+	buffered.Mul(buffered, big.NewInt(int64(100+bufferPct)))
+	buffered.Add(buffered, big.NewInt(99))
+	buffered.Div(buffered, big.NewInt(100))
+	if !buffered.IsUint64() || buffered.Uint64() > bc.ActionGasLimit {
+		return bc.ActionGasLimit, nil
+	}
+	return buffered.Uint64(), nil
+}
+
+// estimateActionGasConsumptionRequest returns nil if the action type is not supported by EstimateActionGasConsumption
+func estimateActionGasConsumptionRequest(core *iotextypes.ActionCore) *iotexapi.EstimateActionGasConsumptionRequest {
+	req := &iotexapi.EstimateActionGasConsumptionRequest{
+		GasPrice: core.GetGasPrice(),
+	}
+	switch act := core.GetAction().(type) {
+	case *iotextypes.ActionCore_Transfer:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_Transfer{Transfer: act.Transfer}
+	case *iotextypes.ActionCore_Execution:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_Execution{Execution: act.Execution}
+	case *iotextypes.ActionCore_StakeCreate:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeCreate{StakeCreate: act.StakeCreate}
+	case *iotextypes.ActionCore_StakeUnstake:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeUnstake{StakeUnstake: act.StakeUnstake}
+	case *iotextypes.ActionCore_StakeWithdraw:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeWithdraw{StakeWithdraw: act.StakeWithdraw}
+	case *iotextypes.ActionCore_StakeAddDeposit:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeAddDeposit{StakeAddDeposit: act.StakeAddDeposit}
+	case *iotextypes.ActionCore_StakeRestake:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeRestake{StakeRestake: act.StakeRestake}
+	case *iotextypes.ActionCore_StakeChangeCandidate:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeChangeCandidate{StakeChangeCandidate: act.StakeChangeCandidate}
+	case *iotextypes.ActionCore_StakeTransferOwnership:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_StakeTransferOwnership{StakeTransferOwnership: act.StakeTransferOwnership}
+	case *iotextypes.ActionCore_CandidateRegister:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_CandidateRegister{CandidateRegister: act.CandidateRegister}
+	case *iotextypes.ActionCore_CandidateUpdate:
+		req.Action = &iotexapi.EstimateActionGasConsumptionRequest_CandidateUpdate{CandidateUpdate: act.CandidateUpdate}
+	default:
+		return nil
+	}
+	return req
+}
+
+// SuggestGasPriceWithMargin returns the suggested gas price raised by marginPercent percent, i.e.,
+// price * (100 + marginPercent) / 100. The result is rounded up, so a non-zero margin is never lost to truncation,
+// and is at least 1 Rau.
+func SuggestGasPriceWithMargin(ctx context.Context, c ServiceClient, marginPercent uint64) (*big.Int, error) {
+	res, err := c.SuggestGasPrice(ctx, &iotexapi.SuggestGasPriceRequest{})
+	if err != nil {
+		return nil, err
+	}
+	price := new(big.Int).SetUint64(res.GetGasPrice())
+	price.Mul(price, new(big.Int).SetUint64(100+marginPercent))
+	price.Add(price, big.NewInt(99))
+	price.Div(price, big.NewInt(100))
+	if price.Sign() == 0 {
+		price.SetInt64(1)
+	}
+	return price, nil
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestEstimateGas(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	sender := identityset.PrivateKey(27).PublicKey()
+
+	t.Run("execution is estimated by consumption", func(t *testing.T) {
+		act := &iotextypes.Action{
+			Core: &iotextypes.ActionCore{
+				GasPrice: "1000",
+				Action: &iotextypes.ActionCore_Execution{
+					Execution: &iotextypes.Execution{Contract: identityset.Address(1).String()},
+				},
+			},
+			SenderPubKey: sender.Bytes(),
+		}
+		c.EXPECT().EstimateActionGasConsumption(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *iotexapi.EstimateActionGasConsumptionRequest, _ ...grpc.CallOption) (*iotexapi.EstimateActionGasConsumptionResponse, error) {
+				require.NotNil(in.GetExecution())
+				require.Equal(sender.Address().String(), in.GetCallerAddress())
+				require.Equal("1000", in.GetGasPrice())
+				return &iotexapi.EstimateActionGasConsumptionResponse{Gas: 21000}, nil
+			})
+		gas, err := EstimateGas(ctx, c, act)
+		require.NoError(err)
+		require.EqualValues(21000, gas)
+
+		act.SenderPubKey = nil
+		_, err = EstimateGas(ctx, c, act)
+		require.Error(err)
+	})
+
+	t.Run("transfer is estimated by consumption", func(t *testing.T) {
+		act := &iotextypes.Action{
+			Core: &iotextypes.ActionCore{
+				Action: &iotextypes.ActionCore_Transfer{
+					Transfer: &iotextypes.Transfer{Amount: "1", Recipient: identityset.Address(1).String()},
+				},
+			},
+		}
+		c.EXPECT().EstimateActionGasConsumption(gomock.Any(), gomock.Any()).Return(
+			&iotexapi.EstimateActionGasConsumptionResponse{Gas: 10000}, nil)
+		gas, err := EstimateGas(ctx, c, act)
+		require.NoError(err)
+		require.EqualValues(10000, gas)
+	})
+
+	t.Run("other actions are estimated by sealed action", func(t *testing.T) {
+		act := &iotextypes.Action{
+			Core: &iotextypes.ActionCore{
+				Action: &iotextypes.ActionCore_ClaimFromRewardingFund{
+					ClaimFromRewardingFund: &iotextypes.ClaimFromRewardingFund{Amount: "1"},
+				},
+			},
+		}
+		c.EXPECT().EstimateGasForAction(gomock.Any(), gomock.Any()).Return(
+			&iotexapi.EstimateGasForActionResponse{Gas: 10000}, nil)
+		gas, err := EstimateGas(ctx, c, act)
+		require.NoError(err)
+		require.EqualValues(10000, gas)
+	})
+
+	t.Run("nil core", func(t *testing.T) {
+		_, err := EstimateGas(ctx, c, &iotextypes.Action{})
+		require.Error(err)
+	})
+}
+
+func TestEstimateGasWithinLimits(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	bc := genesis.Blockchain{ActionGasLimit: 20000, BlockGasLimit: 15000}
+	act := &iotextypes.Action{
+		Core: &iotextypes.ActionCore{
+			Action: &iotextypes.ActionCore_Transfer{
+				Transfer: &iotextypes.Transfer{Amount: "1", Recipient: identityset.Address(1).String()},
+			},
+		},
+	}
+	for _, v := range []struct {
+		gas     uint64
+		success bool
+	}{
+		{10000, true},
+		{15000, true},
+		{16000, false},
+		{21000, false},
+	} {
+		c.EXPECT().EstimateActionGasConsumption(gomock.Any(), gomock.Any()).Return(
+			&iotexapi.EstimateActionGasConsumptionResponse{Gas: v.gas}, nil)
+		gas, err := EstimateGasWithinLimits(ctx, c, act, &bc)
+		if v.success {
+			require.NoError(err)
+			require.Equal(v.gas, gas)
+		} else {
+			require.Error(err)
+		}
+	}
+}
+
+func TestEstimateGasWithBuffer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	// the limits of a network other than the mainnet
+	limit := uint64(20000)
+	bc := genesis.Blockchain{ActionGasLimit: limit, BlockGasLimit: 2 * limit}
+	act := &iotextypes.Action{
+		Core: &iotextypes.ActionCore{
+			Action: &iotextypes.ActionCore_Transfer{
+				Transfer: &iotextypes.Transfer{Amount: "1", Recipient: identityset.Address(1).String()},
+			},
+		},
+	}
+	for _, v := range []struct {
+		gas      uint64
+		buffer   int
+		expected uint64
+	}{
+		{10000, 0, 10000},
+		{10000, 20, 12000},
+		{10001, 10, 11002},
+		{19000, 10, limit},
+		{limit - 1, 10, limit},
+		{limit, 0, limit},
+	} {
+		c.EXPECT().EstimateActionGasConsumption(gomock.Any(), gomock.Any()).Return(
+			&iotexapi.EstimateActionGasConsumptionResponse{Gas: v.gas}, nil).Times(1)
+		gas, err := EstimateGasWithBuffer(ctx, c, act, &bc, v.buffer)
+		require.NoError(err)
+		require.Equal(v.expected, gas)
+	}
+
+	c.EXPECT().EstimateActionGasConsumption(gomock.Any(), gomock.Any()).Return(
+		&iotexapi.EstimateActionGasConsumptionResponse{Gas: limit + 1}, nil).Times(1)
+	_, err := EstimateGasWithBuffer(ctx, c, act, &bc, 10)
+	require.ErrorContains(err, "exceeds action gas limit")
+	c.EXPECT().EstimateActionGasConsumption(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable")).Times(1)
+	_, err = EstimateGasWithBuffer(ctx, c, act, &bc, 10)
+	require.Error(err)
+	_, err = EstimateGasWithBuffer(ctx, c, act, &bc, -1)
+	require.ErrorContains(err, "invalid gas buffer percent -1")
+}
+
+func TestSuggestGasPriceWithMargin(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	for _, v := range []struct {
+		price, margin uint64
+		expected      int64
+	}{
+		{1000000000000, 0, 1000000000000},
+		{1000000000000, 20, 1200000000000},
+		{1000000000000, 100, 2000000000000},
+		{101, 10, 112},
+		{0, 10, 1},
+	} {
+		c.EXPECT().SuggestGasPrice(gomock.Any(), gomock.Any()).Return(&iotexapi.SuggestGasPriceResponse{GasPrice: v.price}, nil)
+		price, err := SuggestGasPriceWithMargin(ctx, c, v.margin)
+		require.NoError(err)
+		require.Equal(big.NewInt(v.expected), price)
+	}
+
+	c.EXPECT().SuggestGasPrice(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, err := SuggestGasPriceWithMargin(ctx, c, 10)
+	require.Error(err)
+}
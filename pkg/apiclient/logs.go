@@ -0,0 +1,166 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// _subscribeLogsMinBackoff and _subscribeLogsMaxBackoff bound the interval between the reconnections of
+	// SubscribeLogs, which doubles on each failure in a row
+	_subscribeLogsMinBackoff = time.Second
+	_subscribeLogsMaxBackoff = time.Minute
+)
+
+// FilterLogsByEvent gets the logs of the event eventName defined in abiJSON, emitted in blocks from fromBlock to
+// toBlock. The first topic is the id of the event signature, and the indexed args are encoded into the following
+// topics in the order of the indexed inputs of the event. A nil arg matches any value, and an address.Address arg is
+// encoded as the corresponding 20-byte address.
+func FilterLogsByEvent(ctx context.Context, c ServiceClient, abiJSON, eventName string, fromBlock, toBlock uint64, indexed ...interface{}) (*iotexapi.GetLogsResponse, error) {
+	contractABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse abi")
+	}
+	event, ok := contractABI.Events[eventName]
+	if !ok {
+		return nil, errors.Errorf("event %s is not found in abi", eventName)
+	}
+	if event.Anonymous {
+		return nil, errors.Errorf("anonymous event %s has no signature topic", eventName)
+	}
+	var numIndexed int
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			numIndexed++
+		}
+	}
+	if len(indexed) > numIndexed {
+		return nil, errors.Errorf("event %s has %d indexed inputs, but %d args are given", eventName, numIndexed, len(indexed))
+	}
+	query := [][]interface{}{{event.ID}}
+	for _, arg := range indexed {
+		switch v := arg.(type) {
+		case nil:
+			query = append(query, nil)
+		case address.Address:
+			query = append(query, []interface{}{common.BytesToAddress(v.Bytes())})
+		default:
+			query = append(query, []interface{}{v})
+		}
+	}
+	topics, err := abi.MakeTopics(query...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode the indexed args of event %s", eventName)
+	}
+	filter := &iotexapi.LogsFilter{
+		Topics: make([]*iotexapi.Topics, len(topics)),
+	}
+	for i, rules := range topics {
+		filter.Topics[i] = &iotexapi.Topics{}
+		for _, topic := range rules {
+			filter.Topics[i].Topic = append(filter.Topics[i].Topic, topic.Bytes())
+		}
+	}
+	return c.GetLogs(ctx, &iotexapi.GetLogsRequest{
+		Filter: filter,
+		Lookup: &iotexapi.GetLogsRequest_ByRange{
+			ByRange: &iotexapi.GetLogsByRange{FromBlock: fromBlock, ToBlock: toBlock},
+		},
+	})
+}
+
+// SubscribeLogs streams the logs matching the filter of req by StreamLogs, and reconnects with an exponential backoff
+// once the stream breaks, which is reset as soon as a log is received. The stream delivers the logs in height order,
+// so a log of a height below the last one delivered, or of the same height, action and index as one delivered, is
+// a duplicate resent after reconnecting, and is dropped. Only the errors that retrying won't fix, i.e., an invalid
+// argument, an unimplemented method or a permission error, stop the subscription, besides ctx being done. The log
+// channel is closed once the subscription stops, and then the error channel yields the error which stops it, and is
+// closed as well.
+func SubscribeLogs(ctx context.Context, c ServiceClient, req *iotexapi.StreamLogsRequest) (<-chan *iotextypes.Log, <-chan error) {
+	logs := make(chan *iotextypes.Log)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(logs)
+		var (
+			backoff    = _subscribeLogsMinBackoff
+			lastHeight uint64
+			// the logs delivered at lastHeight
+			delivered []*iotextypes.Log
+		)
+		isDuplicate := func(l *iotextypes.Log) bool {
+			if l.GetBlkHeight() != lastHeight {
+				return l.GetBlkHeight() < lastHeight
+			}
+			for _, d := range delivered {
+				if d.GetIndex() == l.GetIndex() && bytes.Equal(d.GetActHash(), l.GetActHash()) {
+					return true
+				}
+			}
+			return false
+		}
+		for {
+			err := func() error {
+				stream, err := c.StreamLogs(ctx, req)
+				if err != nil {
+					return err
+				}
+				for {
+					res, err := stream.Recv()
+					if err != nil {
+						return err
+					}
+					backoff = _subscribeLogsMinBackoff
+					l := res.GetLog()
+					if l == nil || isDuplicate(l) {
+						continue
+					}
+					if l.GetBlkHeight() != lastHeight {
+						lastHeight, delivered = l.GetBlkHeight(), delivered[:0]
+					}
+					delivered = append(delivered, l)
+					select {
+					case logs <- l:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+			}()
+			if ctx.Err() != nil {
+				errs <- ctx.Err()
+				return
+			}
+			switch status.Code(err) {
+			case codes.InvalidArgument, codes.Unimplemented, codes.PermissionDenied, codes.Unauthenticated:
+				errs <- errors.Wrap(err, "failed to stream logs")
+				return
+			}
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+			if backoff *= 2; backoff > _subscribeLogsMaxBackoff {
+				backoff = _subscribeLogsMaxBackoff
+			}
+		}
+	}()
+	return logs, errs
+}
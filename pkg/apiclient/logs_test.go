@@ -0,0 +1,171 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+const _transferEventABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`
+
+func TestFilterLogsByEvent(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	transferTopic, err := hex.DecodeString("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	require.NoError(err)
+	to := identityset.Address(1)
+	toTopic := append(make([]byte, 12), to.Bytes()...)
+
+	c.EXPECT().GetLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.GetLogsRequest, _ ...grpc.CallOption) (*iotexapi.GetLogsResponse, error) {
+			require.EqualValues(10, in.GetByRange().GetFromBlock())
+			require.EqualValues(20, in.GetByRange().GetToBlock())
+			require.Empty(in.GetFilter().GetAddress())
+			topics := in.GetFilter().GetTopics()
+			require.Len(topics, 3)
+			require.Equal([][]byte{transferTopic}, topics[0].GetTopic())
+			// any sender
+			require.Empty(topics[1].GetTopic())
+			require.Equal([][]byte{toTopic}, topics[2].GetTopic())
+			return &iotexapi.GetLogsResponse{}, nil
+		})
+	_, err = FilterLogsByEvent(ctx, c, _transferEventABI, "Transfer", 10, 20, nil, to)
+	require.NoError(err)
+
+	c.EXPECT().GetLogs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.GetLogsRequest, _ ...grpc.CallOption) (*iotexapi.GetLogsResponse, error) {
+			require.Len(in.GetFilter().GetTopics(), 1)
+			return &iotexapi.GetLogsResponse{}, nil
+		})
+	_, err = FilterLogsByEvent(ctx, c, _transferEventABI, "Transfer", 10, 20)
+	require.NoError(err)
+
+	for _, v := range []struct {
+		abiJSON, event string
+		indexed        []interface{}
+	}{
+		{"invalid abi", "Transfer", nil},
+		{_transferEventABI, "Approval", nil},
+		{_transferEventABI, "Transfer", []interface{}{to, to, big.NewInt(1)}},
+		{_transferEventABI, "Transfer", []interface{}{[]int{1}}},
+	} {
+		_, err = FilterLogsByEvent(ctx, c, v.abiJSON, v.event, 10, 20, v.indexed...)
+		require.Error(err)
+	}
+}
+
+// logStream replays the logs, and then fails with err
+type logStream struct {
+	grpc.ClientStream
+	logs []*iotextypes.Log
+	err  error
+}
+
+func (s *logStream) Recv() (*iotexapi.StreamLogsResponse, error) {
+	if len(s.logs) == 0 {
+		return nil, s.err
+	}
+	l := s.logs[0]
+	s.logs = s.logs[1:]
+	return &iotexapi.StreamLogsResponse{Log: l}, nil
+}
+
+func TestSubscribeLogs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	minBackoff, maxBackoff := _subscribeLogsMinBackoff, _subscribeLogsMaxBackoff
+	_subscribeLogsMinBackoff, _subscribeLogsMaxBackoff = time.Millisecond, 4*time.Millisecond
+	defer func() {
+		_subscribeLogsMinBackoff, _subscribeLogsMaxBackoff = minBackoff, maxBackoff
+	}()
+
+	newLog := func(height uint64, actHash byte, index uint32) *iotextypes.Log {
+		return &iotextypes.Log{BlkHeight: height, ActHash: []byte{actHash}, Index: index}
+	}
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	req := &iotexapi.StreamLogsRequest{Filter: &iotexapi.LogsFilter{}}
+
+	t.Run("dedup across reconnections", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		gomock.InOrder(
+			c.EXPECT().StreamLogs(gomock.Any(), req).Return(&logStream{
+				logs: []*iotextypes.Log{newLog(1, 1, 0), newLog(2, 1, 0)},
+				err:  status.Error(codes.Unavailable, "connection reset"),
+			}, nil).Times(1),
+			c.EXPECT().StreamLogs(gomock.Any(), req).Return(nil, status.Error(codes.Unavailable, "dial failed")).Times(1),
+			// the server resends the logs of height 2
+			c.EXPECT().StreamLogs(gomock.Any(), req).Return(&logStream{
+				logs: []*iotextypes.Log{newLog(1, 1, 0), newLog(2, 1, 0), newLog(2, 2, 0), newLog(3, 1, 0)},
+				err:  io.EOF,
+			}, nil).Times(1),
+			c.EXPECT().StreamLogs(gomock.Any(), req).DoAndReturn(
+				func(ctx context.Context, _ *iotexapi.StreamLogsRequest, _ ...grpc.CallOption) (iotexapi.APIService_StreamLogsClient, error) {
+					cancel()
+					return nil, ctx.Err()
+				}).Times(1),
+		)
+		logs, errs := SubscribeLogs(ctx, c, req)
+		var received []*iotextypes.Log
+		for l := range logs {
+			received = append(received, l)
+		}
+		require.Equal([]*iotextypes.Log{newLog(1, 1, 0), newLog(2, 1, 0), newLog(2, 2, 0), newLog(3, 1, 0)}, received)
+		require.ErrorIs(<-errs, context.Canceled)
+		_, ok := <-errs
+		require.False(ok)
+	})
+
+	t.Run("unretriable error", func(t *testing.T) {
+		c.EXPECT().StreamLogs(gomock.Any(), req).Return(&logStream{
+			logs: []*iotextypes.Log{newLog(1, 1, 0)},
+			err:  status.Error(codes.InvalidArgument, "invalid filter"),
+		}, nil).Times(1)
+		logs, errs := SubscribeLogs(context.Background(), c, req)
+		require.Equal(newLog(1, 1, 0), <-logs)
+		_, ok := <-logs
+		require.False(ok)
+		err := <-errs
+		require.Equal(codes.InvalidArgument, status.Code(errors.Cause(err)))
+	})
+
+	t.Run("cancel while delivering", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		c.EXPECT().StreamLogs(gomock.Any(), req).Return(&logStream{
+			logs: []*iotextypes.Log{newLog(1, 1, 0), newLog(1, 1, 1)},
+			err:  io.EOF,
+		}, nil).Times(1)
+		logs, errs := SubscribeLogs(ctx, c, req)
+		require.Equal(newLog(1, 1, 0), <-logs)
+		cancel()
+		require.ErrorIs(<-errs, context.Canceled)
+		for range logs {
+		}
+	})
+}
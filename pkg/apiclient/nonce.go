@@ -0,0 +1,60 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NonceGap reports whether the actions of addr queued in the actpool are stalled by a nonce gap, i.e., an action is
+// queued with a nonce beyond the pending nonce, which can't be packed into a block until the missing nonces land.
+// pendingNonce is the PendingNonce of AccountMeta, the nonce following the consecutive actions queued on top of the
+// chain, and confirmedNonce is the nonce of the next action to be confirmed on chain. The api server doesn't fill the
+// deprecated Nonce of AccountMeta, so confirmedNonce is derived from the queued actions instead.
+func NonceGap(ctx context.Context, c ServiceClient, addr string) (confirmedNonce, pendingNonce uint64, hasGap bool, err error) {
+	res, err := c.GetAccount(ctx, &iotexapi.GetAccountRequest{Address: addr})
+	if err != nil {
+		return 0, 0, false, errors.Wrapf(err, "failed to get account %s", addr)
+	}
+	pendingNonce = res.GetAccountMeta().GetPendingNonce()
+	var numConsecutive uint64
+	for start := uint64(0); ; start += MaxActionsPerRequest {
+		if err := ctx.Err(); err != nil {
+			return 0, 0, false, err
+		}
+		res, err := c.GetActions(ctx, &iotexapi.GetActionsRequest{
+			Lookup: &iotexapi.GetActionsRequest_UnconfirmedByAddr{
+				UnconfirmedByAddr: &iotexapi.GetUnconfirmedActionsByAddressRequest{Address: addr, Start: start, Count: MaxActionsPerRequest},
+			},
+		})
+		if err != nil {
+			if start > 0 && status.Code(err) == codes.InvalidArgument {
+				// the previous page ended exactly at the last queued action
+				break
+			}
+			return 0, 0, false, errors.Wrapf(err, "failed to get unconfirmed actions of %s", addr)
+		}
+		for _, info := range res.GetActionInfo() {
+			if nonce := info.GetAction().GetCore().GetNonce(); nonce < pendingNonce {
+				numConsecutive++
+			} else if nonce > pendingNonce {
+				hasGap = true
+			}
+		}
+		if uint64(len(res.GetActionInfo())) < MaxActionsPerRequest {
+			break
+		}
+	}
+	if numConsecutive > pendingNonce {
+		return 0, 0, false, errors.Errorf("%d actions of %s are queued below pending nonce %d", numConsecutive, addr, pendingNonce)
+	}
+	return pendingNonce - numConsecutive, pendingNonce, hasGap, nil
+}
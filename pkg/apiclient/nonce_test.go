@@ -0,0 +1,89 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestNonceGap(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	addr := identityset.Address(1).String()
+	expectQueue := func(pendingNonce uint64, nonces []uint64) {
+		c.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(&iotexapi.GetAccountResponse{
+			AccountMeta: &iotextypes.AccountMeta{Address: addr, PendingNonce: pendingNonce},
+		}, nil)
+		c.EXPECT().GetActions(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *iotexapi.GetActionsRequest, _ ...grpc.CallOption) (*iotexapi.GetActionsResponse, error) {
+				req := in.GetUnconfirmedByAddr()
+				require.Equal(addr, req.GetAddress())
+				if len(nonces) > 0 && req.GetStart() >= uint64(len(nonces)) {
+					return nil, status.Error(codes.InvalidArgument, "start exceeds the limit")
+				}
+				infos := []*iotexapi.ActionInfo{}
+				for i := req.GetStart(); i < req.GetStart()+req.GetCount() && i < uint64(len(nonces)); i++ {
+					infos = append(infos, &iotexapi.ActionInfo{
+						Action: &iotextypes.Action{Core: &iotextypes.ActionCore{Nonce: nonces[i]}},
+					})
+				}
+				return &iotexapi.GetActionsResponse{ActionInfo: infos}, nil
+			}).Times(len(nonces)/int(MaxActionsPerRequest) + 1)
+	}
+	consecutive := func(from, to uint64) []uint64 {
+		var nonces []uint64
+		for n := from; n < to; n++ {
+			nonces = append(nonces, n)
+		}
+		return nonces
+	}
+
+	for _, v := range []struct {
+		pendingNonce uint64
+		nonces       []uint64
+		confirmed    uint64
+		hasGap       bool
+	}{
+		{5, nil, 5, false},
+		{8, []uint64{5, 6, 7}, 5, false},
+		{8, []uint64{5, 6, 7, 9, 10}, 5, true},
+		{5, []uint64{7}, 5, true},
+		{1005, consecutive(5, 1005), 5, false},
+		{1005, append(consecutive(5, 1005), 1006), 5, true},
+	} {
+		expectQueue(v.pendingNonce, v.nonces)
+		confirmed, pending, hasGap, err := NonceGap(ctx, c, addr)
+		require.NoError(err)
+		require.Equal(v.confirmed, confirmed)
+		require.Equal(v.pendingNonce, pending)
+		require.Equal(v.hasGap, hasGap)
+	}
+
+	expectQueue(1, []uint64{0, 0})
+	_, _, _, err := NonceGap(ctx, c, addr)
+	require.ErrorContains(err, "2 actions of "+addr+" are queued below pending nonce 1")
+
+	c.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, _, _, err = NonceGap(ctx, c, addr)
+	require.ErrorContains(err, "unavailable")
+}
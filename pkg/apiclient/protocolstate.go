@@ -0,0 +1,130 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	_stakingProtocol   = "staking"
+	_pollProtocol      = "poll"
+	_rewardingProtocol = "rewarding"
+)
+
+// ReadStakingBuckets returns the staking buckets in the range of offset and limit
+func ReadStakingBuckets(ctx context.Context, c ServiceClient, offset, limit uint32) (*iotextypes.VoteBucketList, error) {
+	buckets := &iotextypes.VoteBucketList{}
+	if err := readStakingData(ctx, c, iotexapi.ReadStakingDataMethod_BUCKETS, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_Buckets{
+			Buckets: &iotexapi.ReadStakingDataRequest_VoteBuckets{
+				Pagination: &iotexapi.PaginationParam{Offset: offset, Limit: limit},
+			},
+		},
+	}, buckets); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// ReadStakingBucketsByVoter returns the staking buckets owned by voter in the range of offset and limit
+func ReadStakingBucketsByVoter(ctx context.Context, c ServiceClient, voter address.Address, offset, limit uint32) (*iotextypes.VoteBucketList, error) {
+	buckets := &iotextypes.VoteBucketList{}
+	if err := readStakingData(ctx, c, iotexapi.ReadStakingDataMethod_BUCKETS_BY_VOTER, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_BucketsByVoter{
+			BucketsByVoter: &iotexapi.ReadStakingDataRequest_VoteBucketsByVoter{
+				VoterAddress: voter.String(),
+				Pagination:   &iotexapi.PaginationParam{Offset: offset, Limit: limit},
+			},
+		},
+	}, buckets); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// ReadStakingCandidates returns the staking candidates in the range of offset and limit
+func ReadStakingCandidates(ctx context.Context, c ServiceClient, offset, limit uint32) (*iotextypes.CandidateListV2, error) {
+	candidates := &iotextypes.CandidateListV2{}
+	if err := readStakingData(ctx, c, iotexapi.ReadStakingDataMethod_CANDIDATES, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_Candidates_{
+			Candidates: &iotexapi.ReadStakingDataRequest_Candidates{
+				Pagination: &iotexapi.PaginationParam{Offset: offset, Limit: limit},
+			},
+		},
+	}, candidates); err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// ReadActiveCandidates returns the active block producers of the current epoch
+func ReadActiveCandidates(ctx context.Context, c ServiceClient) ([]*iotextypes.Candidate, error) {
+	meta, err := c.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	epoch := meta.GetChainMeta().GetEpoch().GetNum()
+	res, err := DoReadState(ctx, c, NewReadStateRequestBuilder().
+		Protocol(_pollProtocol).
+		Method("ActiveBlockProducersByEpoch").
+		Args([]byte(strconv.FormatUint(epoch, 10))))
+	if err != nil {
+		return nil, err
+	}
+	candidates := &iotextypes.CandidateList{}
+	if err := proto.Unmarshal(res.GetData(), candidates); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal candidate list")
+	}
+	return candidates.GetCandidates(), nil
+}
+
+// ReadUnclaimedBalance returns the unclaimed reward of addr in Rau
+func ReadUnclaimedBalance(ctx context.Context, c ServiceClient, addr address.Address) (*big.Int, error) {
+	res, err := DoReadState(ctx, c, NewReadStateRequestBuilder().
+		Protocol(_rewardingProtocol).
+		Method("UnclaimedBalance").
+		Args([]byte(addr.String())))
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := new(big.Int).SetString(string(res.GetData()), 10)
+	if !ok {
+		return nil, errors.Errorf("invalid unclaimed balance %s", res.GetData())
+	}
+	return balance, nil
+}
+
+// readStakingData reads the staking data of method with the request, and unmarshals the response into out
+func readStakingData(ctx context.Context, c ServiceClient, method iotexapi.ReadStakingDataMethod_Name, req *iotexapi.ReadStakingDataRequest, out proto.Message) error {
+	methodName, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: method})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal staking method")
+	}
+	arg, err := proto.Marshal(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal staking request")
+	}
+	res, err := DoReadState(ctx, c, NewReadStateRequestBuilder().
+		Protocol(_stakingProtocol).
+		Method(string(methodName)).
+		Args(arg))
+	if err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(res.GetData(), out); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal the response of %s", method)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestReadStakingData(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	voter := identityset.Address(1)
+	expectRead := func(method iotexapi.ReadStakingDataMethod_Name, check func(*iotexapi.ReadStakingDataRequest), out proto.Message) {
+		data, err := proto.Marshal(out)
+		require.NoError(err)
+		c.EXPECT().ReadState(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *iotexapi.ReadStateRequest, _ ...grpc.CallOption) (*iotexapi.ReadStateResponse, error) {
+				require.Equal([]byte("staking"), in.GetProtocolID())
+				m := &iotexapi.ReadStakingDataMethod{}
+				require.NoError(proto.Unmarshal(in.GetMethodName(), m))
+				require.Equal(method, m.GetMethod())
+				require.Len(in.GetArguments(), 1)
+				req := &iotexapi.ReadStakingDataRequest{}
+				require.NoError(proto.Unmarshal(in.GetArguments()[0], req))
+				check(req)
+				return &iotexapi.ReadStateResponse{Data: data}, nil
+			})
+	}
+
+	bucketList := &iotextypes.VoteBucketList{Buckets: []*iotextypes.VoteBucket{{Index: 1}, {Index: 2}}}
+	expectRead(iotexapi.ReadStakingDataMethod_BUCKETS, func(req *iotexapi.ReadStakingDataRequest) {
+		require.EqualValues(10, req.GetBuckets().GetPagination().GetOffset())
+		require.EqualValues(2, req.GetBuckets().GetPagination().GetLimit())
+	}, bucketList)
+	buckets, err := ReadStakingBuckets(ctx, c, 10, 2)
+	require.NoError(err)
+	require.True(proto.Equal(bucketList, buckets))
+
+	expectRead(iotexapi.ReadStakingDataMethod_BUCKETS_BY_VOTER, func(req *iotexapi.ReadStakingDataRequest) {
+		require.Equal(voter.String(), req.GetBucketsByVoter().GetVoterAddress())
+	}, bucketList)
+	buckets, err = ReadStakingBucketsByVoter(ctx, c, voter, 0, 10)
+	require.NoError(err)
+	require.True(proto.Equal(bucketList, buckets))
+
+	candList := &iotextypes.CandidateListV2{Candidates: []*iotextypes.CandidateV2{{Name: "alice"}}}
+	expectRead(iotexapi.ReadStakingDataMethod_CANDIDATES, func(req *iotexapi.ReadStakingDataRequest) {
+		require.EqualValues(5, req.GetCandidates().GetPagination().GetLimit())
+	}, candList)
+	candidates, err := ReadStakingCandidates(ctx, c, 0, 5)
+	require.NoError(err)
+	require.True(proto.Equal(candList, candidates))
+
+	c.EXPECT().ReadState(gomock.Any(), gomock.Any()).Return(&iotexapi.ReadStateResponse{Data: []byte{0xff}}, nil)
+	_, err = ReadStakingBuckets(ctx, c, 0, 10)
+	require.Error(err)
+	c.EXPECT().ReadState(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, err = ReadStakingCandidates(ctx, c, 0, 10)
+	require.Error(err)
+}
+
+func TestReadActiveCandidates(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	candList := &iotextypes.CandidateList{Candidates: []*iotextypes.Candidate{
+		{Address: identityset.Address(1).String(), Votes: []byte{1}},
+		{Address: identityset.Address(2).String(), Votes: []byte{2}},
+	}}
+	data, err := proto.Marshal(candList)
+	require.NoError(err)
+	c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+		ChainMeta: &iotextypes.ChainMeta{Epoch: &iotextypes.EpochData{Num: 42}},
+	}, nil)
+	c.EXPECT().ReadState(gomock.Any(), &iotexapi.ReadStateRequest{
+		ProtocolID: []byte("poll"),
+		MethodName: []byte("ActiveBlockProducersByEpoch"),
+		Arguments:  [][]byte{[]byte("42")},
+	}).Return(&iotexapi.ReadStateResponse{Data: data}, nil)
+	candidates, err := ReadActiveCandidates(ctx, c)
+	require.NoError(err)
+	require.Len(candidates, 2)
+	require.Equal(identityset.Address(2).String(), candidates[1].GetAddress())
+
+	c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, err = ReadActiveCandidates(ctx, c)
+	require.Error(err)
+}
+
+func TestReadUnclaimedBalance(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	addr := identityset.Address(1)
+	c.EXPECT().ReadState(gomock.Any(), &iotexapi.ReadStateRequest{
+		ProtocolID: []byte("rewarding"),
+		MethodName: []byte("UnclaimedBalance"),
+		Arguments:  [][]byte{[]byte(addr.String())},
+	}).Return(&iotexapi.ReadStateResponse{Data: []byte("1000")}, nil)
+	balance, err := ReadUnclaimedBalance(ctx, c, addr)
+	require.NoError(err)
+	require.Equal(big.NewInt(1000), balance)
+
+	c.EXPECT().ReadState(gomock.Any(), gomock.Any()).Return(&iotexapi.ReadStateResponse{Data: []byte("abc")}, nil)
+	_, err = ReadUnclaimedBalance(ctx, c, addr)
+	require.Error(err)
+}
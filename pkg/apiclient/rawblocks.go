@@ -0,0 +1,138 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+)
+
+// MaxRawBlocksPerRequest is the max number of blocks requested by one GetRawBlocks call, which is the default range
+// query limit of the api server
+const MaxRawBlocksPerRequest uint64 = 1000
+
+// RawBlocks returns at most count blocks starting from height start in height order, decoded from the responses of
+// GetRawBlocks. The range is requested in chunks of MaxRawBlocksPerRequest blocks, and fewer blocks are returned if
+// the range goes beyond the tip. The receipts of the blocks are attached if withReceipts is true. The EVM network ID
+// is needed to decode the actions in web3 format.
+func RawBlocks(ctx context.Context, c iotexapi.APIServiceClient, start, count uint64, withReceipts bool, evmNetworkID uint32) ([]*block.Block, error) {
+	if count == 0 {
+		return nil, errors.New("count must be greater than zero")
+	}
+	var (
+		deser = block.NewDeserializer(evmNetworkID)
+		blks  = make([]*block.Block, 0, count)
+	)
+	for count > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		n := count
+		if n > MaxRawBlocksPerRequest {
+			n = MaxRawBlocksPerRequest
+		}
+		res, err := c.GetRawBlocks(ctx, &iotexapi.GetRawBlocksRequest{
+			StartHeight:  start,
+			Count:        n,
+			WithReceipts: withReceipts,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get blocks from height %d", start)
+		}
+		for i, info := range res.GetBlocks() {
+			height := start + uint64(i)
+			blk, err := deser.FromBlockProto(info.GetBlock())
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode block %d", height)
+			}
+			if blk.Height() != height {
+				return nil, errors.Errorf("expect block at height %d, got %d", height, blk.Height())
+			}
+			if err := blk.VerifyTxRoot(); err != nil {
+				return nil, errors.Wrapf(err, "invalid block %d", height)
+			}
+			if withReceipts {
+				if len(info.GetReceipts()) != len(blk.Actions) {
+					return nil, errors.Errorf("block %d has %d actions, but %d receipts", height, len(blk.Actions), len(info.GetReceipts()))
+				}
+				for _, receiptPb := range info.GetReceipts() {
+					receipt := &action.Receipt{}
+					receipt.ConvertFromReceiptPb(receiptPb)
+					blk.Receipts = append(blk.Receipts, receipt)
+				}
+			}
+			blks = append(blks, blk)
+		}
+		if uint64(len(res.GetBlocks())) < n {
+			// reached the tip
+			break
+		}
+		start += n
+		count -= n
+	}
+	return blks, nil
+}
+
+// StreamRawBlocks streams the blocks from height start to end inclusively in height order, which are requested by
+// GetRawBlocks in chunks of MaxRawBlocksPerRequest blocks. The stream stops early at the tip. A chunk is not requested
+// until the blocks ahead are taken from the channel, so a slow consumer holds the requests back. The block channel is
+// closed once the stream stops, and then the error channel yields the error which stops the stream, if any, and is
+// closed as well.
+func StreamRawBlocks(ctx context.Context, c ServiceClient, start, end uint64, withReceipts bool) (<-chan *iotexapi.BlockInfo, <-chan error) {
+	blks := make(chan *iotexapi.BlockInfo)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(blks)
+		if start > end {
+			errs <- errors.Errorf("start height %d is greater than end height %d", start, end)
+			return
+		}
+		for {
+			if err := ctx.Err(); err != nil {
+				errs <- err
+				return
+			}
+			n := MaxRawBlocksPerRequest
+			if end-start < n {
+				n = end - start + 1
+			}
+			res, err := c.GetRawBlocks(ctx, &iotexapi.GetRawBlocksRequest{
+				StartHeight:  start,
+				Count:        n,
+				WithReceipts: withReceipts,
+			})
+			if err != nil {
+				errs <- errors.Wrapf(err, "failed to get blocks from height %d", start)
+				return
+			}
+			for i, info := range res.GetBlocks() {
+				height := start + uint64(i)
+				if h := info.GetBlock().GetHeader().GetCore().GetHeight(); h != height {
+					errs <- errors.Errorf("expect block at height %d, got %d", height, h)
+					return
+				}
+				select {
+				case blks <- info:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+			if uint64(len(res.GetBlocks())) < n || end-start < n {
+				// reached the tip or the end
+				return
+			}
+			start += n
+		}
+	}()
+	return blks, errs
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestRawBlocks(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 1, big.NewInt(20), []byte{}, 100000, big.NewInt(10))
+	require.NoError(err)
+	const tip = 1005
+	blkPbs := make([]*iotextypes.Block, tip+1)
+	for h := uint64(1); h <= tip; h++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(h).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(tsf).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		blkPbs[h] = blk.ConvertToBlockPb()
+	}
+	c.EXPECT().GetRawBlocks(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.GetRawBlocksRequest, _ ...grpc.CallOption) (*iotexapi.GetRawBlocksResponse, error) {
+			require.LessOrEqual(in.GetCount(), MaxRawBlocksPerRequest)
+			infos := []*iotexapi.BlockInfo{}
+			for h := in.GetStartHeight(); h < in.GetStartHeight()+in.GetCount() && h <= tip; h++ {
+				info := &iotexapi.BlockInfo{Block: blkPbs[h]}
+				if in.GetWithReceipts() {
+					info.Receipts = []*iotextypes.Receipt{{Status: 1, BlkHeight: h, GasConsumed: 10000}}
+				}
+				infos = append(infos, info)
+			}
+			return &iotexapi.GetRawBlocksResponse{Blocks: infos}, nil
+		}).Times(4)
+
+	for _, v := range []struct {
+		start, count, expected uint64
+		withReceipts           bool
+	}{
+		{1, 10, 10, false},
+		{1, 1200, 1005, false},
+		{1000, 3, 3, true},
+	} {
+		blks, err := RawBlocks(ctx, c, v.start, v.count, v.withReceipts, 0)
+		require.NoError(err)
+		require.Len(blks, int(v.expected))
+		for i, blk := range blks {
+			require.Equal(v.start+uint64(i), blk.Height())
+			require.Len(blk.Actions, 1)
+			if v.withReceipts {
+				require.Len(blk.Receipts, 1)
+				require.Equal(blk.Height(), blk.Receipts[0].BlockHeight)
+				require.EqualValues(10000, blk.Receipts[0].GasConsumed)
+			} else {
+				require.Empty(blk.Receipts)
+			}
+		}
+	}
+
+	_, err = RawBlocks(ctx, c, 1, 0, false, 0)
+	require.Error(err)
+
+	// blocks out of order
+	c.EXPECT().GetRawBlocks(gomock.Any(), gomock.Any()).Return(&iotexapi.GetRawBlocksResponse{
+		Blocks: []*iotexapi.BlockInfo{{Block: blkPbs[2]}, {Block: blkPbs[1]}},
+	}, nil)
+	_, err = RawBlocks(ctx, c, 1, 2, false, 0)
+	require.ErrorContains(err, "expect block at height 1, got 2")
+
+	// missing receipts
+	c.EXPECT().GetRawBlocks(gomock.Any(), gomock.Any()).Return(&iotexapi.GetRawBlocksResponse{
+		Blocks: []*iotexapi.BlockInfo{{Block: blkPbs[1]}},
+	}, nil)
+	_, err = RawBlocks(ctx, c, 1, 1, true, 0)
+	require.ErrorContains(err, "block 1 has 1 actions, but 0 receipts")
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = RawBlocks(ctx, c, 1, 10, false, 0)
+	require.Equal(context.Canceled, err)
+}
+
+func TestStreamRawBlocks(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	tip := uint64(3000)
+	getRawBlocks := func(_ context.Context, in *iotexapi.GetRawBlocksRequest, _ ...grpc.CallOption) (*iotexapi.GetRawBlocksResponse, error) {
+		require.LessOrEqual(in.GetCount(), MaxRawBlocksPerRequest)
+		infos := []*iotexapi.BlockInfo{}
+		for h := in.GetStartHeight(); h < in.GetStartHeight()+in.GetCount() && h <= tip; h++ {
+			infos = append(infos, &iotexapi.BlockInfo{Block: &iotextypes.Block{
+				Header: &iotextypes.BlockHeader{Core: &iotextypes.BlockHeaderCore{Height: h}},
+			}})
+		}
+		return &iotexapi.GetRawBlocksResponse{Blocks: infos}, nil
+	}
+	collect := func(blks <-chan *iotexapi.BlockInfo, errs <-chan error) ([]uint64, error) {
+		heights := []uint64{}
+		for info := range blks {
+			heights = append(heights, info.GetBlock().GetHeader().GetCore().GetHeight())
+		}
+		return heights, <-errs
+	}
+
+	for _, v := range []struct {
+		start, end, tip uint64
+		requests        int
+		expected        uint64
+	}{
+		{10, 2500, 3000, 3, 2491},
+		{1, 2000, 3000, 2, 2000},
+		{1, 1200, 1005, 2, 1005},
+		{1000, 1000, 3000, 1, 1},
+	} {
+		tip = v.tip
+		c.EXPECT().GetRawBlocks(gomock.Any(), gomock.Any()).DoAndReturn(getRawBlocks).Times(v.requests)
+		heights, err := collect(StreamRawBlocks(ctx, c, v.start, v.end, false))
+		require.NoError(err)
+		require.Len(heights, int(v.expected))
+		for i, h := range heights {
+			require.Equal(v.start+uint64(i), h)
+		}
+	}
+
+	_, err := collect(StreamRawBlocks(ctx, c, 2, 1, false))
+	require.ErrorContains(err, "start height 2 is greater than end height 1")
+
+	// blocks out of order
+	c.EXPECT().GetRawBlocks(gomock.Any(), gomock.Any()).Return(&iotexapi.GetRawBlocksResponse{
+		Blocks: []*iotexapi.BlockInfo{{Block: &iotextypes.Block{
+			Header: &iotextypes.BlockHeader{Core: &iotextypes.BlockHeaderCore{Height: 2}},
+		}}},
+	}, nil)
+	_, err = collect(StreamRawBlocks(ctx, c, 1, 2, false))
+	require.ErrorContains(err, "expect block at height 1, got 2")
+
+	c.EXPECT().GetRawBlocks(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, err = collect(StreamRawBlocks(ctx, c, 1, 2, false))
+	require.ErrorContains(err, "unavailable")
+
+	// the consumer stops taking blocks
+	tip = 3000
+	c.EXPECT().GetRawBlocks(gomock.Any(), gomock.Any()).DoAndReturn(getRawBlocks).Times(1)
+	cctx, cancel := context.WithCancel(ctx)
+	blks, errs := StreamRawBlocks(cctx, c, 1, 3000, true)
+	<-blks
+	cancel()
+	_, err = collect(blks, errs)
+	require.ErrorIs(err, context.Canceled)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+)
+
+// ReadStateRequestBuilder is the builder to build a ReadStateRequest
+type ReadStateRequestBuilder struct {
+	protocol string
+	method   string
+	args     [][]byte
+	height   string
+}
+
+// NewReadStateRequestBuilder creates a ReadStateRequestBuilder
+func NewReadStateRequestBuilder() *ReadStateRequestBuilder {
+	return &ReadStateRequestBuilder{}
+}
+
+// Protocol sets the protocol ID, e.g. "staking", "poll" or "rewarding"
+func (b *ReadStateRequestBuilder) Protocol(protocol string) *ReadStateRequestBuilder {
+	b.protocol = protocol
+	return b
+}
+
+// Method sets the method name
+func (b *ReadStateRequestBuilder) Method(method string) *ReadStateRequestBuilder {
+	b.method = method
+	return b
+}
+
+// Args appends the arguments
+func (b *ReadStateRequestBuilder) Args(args ...[]byte) *ReadStateRequestBuilder {
+	b.args = append(b.args, args...)
+	return b
+}
+
+// Height sets the height to read the state at, in decimal string format. An empty height reads the latest state.
+func (b *ReadStateRequestBuilder) Height(height string) *ReadStateRequestBuilder {
+	b.height = height
+	return b
+}
+
+// Build builds the ReadStateRequest
+func (b *ReadStateRequestBuilder) Build() (*iotexapi.ReadStateRequest, error) {
+	if b.protocol == "" {
+		return nil, errors.New("protocol is not set")
+	}
+	if b.method == "" {
+		return nil, errors.New("method is not set")
+	}
+	if b.height != "" {
+		if _, err := strconv.ParseUint(b.height, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "invalid height %s", b.height)
+		}
+	}
+	return &iotexapi.ReadStateRequest{
+		ProtocolID: []byte(b.protocol),
+		MethodName: []byte(b.method),
+		Arguments:  b.args,
+		Height:     b.height,
+	}, nil
+}
+
+// DoReadState builds the request from the builder and reads the state
+func DoReadState(ctx context.Context, c ServiceClient, b *ReadStateRequestBuilder) (*iotexapi.ReadStateResponse, error) {
+	req, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return c.ReadState(ctx, req)
+}
+
+// ReadStateAt reads the state of the protocol method with the args at height, where a nil height reads the latest
+// state. The height is sent in the decimal string format, which the API expects.
+func ReadStateAt(ctx context.Context, c iotexapi.APIServiceClient, protocol, method string, height *uint64, args ...[]byte) (*iotexapi.ReadStateResponse, error) {
+	b := NewReadStateRequestBuilder().Protocol(protocol).Method(method).Args(args...)
+	if height != nil {
+		b.Height(strconv.FormatUint(*height, 10))
+	}
+	return DoReadState(ctx, c, b)
+}
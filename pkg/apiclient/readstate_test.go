@@ -0,0 +1,89 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadStateRequestBuilder(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewReadStateRequestBuilder().Method("TotalBalance").Build()
+	require.Error(err)
+	_, err = NewReadStateRequestBuilder().Protocol("rewarding").Build()
+	require.Error(err)
+	_, err = NewReadStateRequestBuilder().Protocol("rewarding").Method("TotalBalance").Height("0x10").Build()
+	require.Error(err)
+
+	req, err := NewReadStateRequestBuilder().
+		Protocol("poll").
+		Method("ActiveBlockProducersByEpoch").
+		Args([]byte("1")).
+		Args([]byte("2")).
+		Height("100").
+		Build()
+	require.NoError(err)
+	require.Equal([]byte("poll"), req.ProtocolID)
+	require.Equal([]byte("ActiveBlockProducersByEpoch"), req.MethodName)
+	require.Equal([][]byte{[]byte("1"), []byte("2")}, req.Arguments)
+	require.Equal("100", req.Height)
+}
+
+func TestDoReadState(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	b := NewReadStateRequestBuilder().Protocol("rewarding").Method("TotalBalance")
+	c.EXPECT().ReadState(gomock.Any(), &iotexapi.ReadStateRequest{
+		ProtocolID: []byte("rewarding"),
+		MethodName: []byte("TotalBalance"),
+	}).Return(&iotexapi.ReadStateResponse{Data: []byte("100")}, nil)
+	res, err := DoReadState(context.Background(), c, b)
+	require.NoError(err)
+	require.Equal([]byte("100"), res.Data)
+
+	_, err = DoReadState(context.Background(), c, NewReadStateRequestBuilder())
+	require.Error(err)
+}
+
+func TestReadStateAt(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	c.EXPECT().ReadState(gomock.Any(), &iotexapi.ReadStateRequest{
+		ProtocolID: []byte("poll"),
+		MethodName: []byte("ActiveBlockProducersByEpoch"),
+		Arguments:  [][]byte{[]byte("1")},
+	}).Return(&iotexapi.ReadStateResponse{Data: []byte("latest")}, nil)
+	res, err := ReadStateAt(ctx, c, "poll", "ActiveBlockProducersByEpoch", nil, []byte("1"))
+	require.NoError(err)
+	require.Equal([]byte("latest"), res.Data)
+
+	height := uint64(1024)
+	c.EXPECT().ReadState(gomock.Any(), &iotexapi.ReadStateRequest{
+		ProtocolID: []byte("rewarding"),
+		MethodName: []byte("TotalBalance"),
+		Height:     "1024",
+	}).Return(&iotexapi.ReadStateResponse{Data: []byte("100")}, nil)
+	res, err = ReadStateAt(ctx, c, "rewarding", "TotalBalance", &height)
+	require.NoError(err)
+	require.Equal([]byte("100"), res.Data)
+
+	_, err = ReadStateAt(ctx, c, "", "TotalBalance", &height)
+	require.ErrorContains(err, "protocol is not set")
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WaitForReceipt polls the receipt of the action every pollInterval, until the receipt is available or ctx is done.
+// A NotFound error means the action is not minted yet and the polling goes on, while any other error is returned.
+func WaitForReceipt(ctx context.Context, c ServiceClient, actionHash string, pollInterval time.Duration) (*iotextypes.Receipt, error) {
+	if pollInterval <= 0 {
+		return nil, errors.Errorf("invalid poll interval %s", pollInterval)
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		res, err := c.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{ActionHash: actionHash})
+		switch {
+		case err == nil:
+			return res.GetReceiptInfo().GetReceipt(), nil
+		case status.Code(err) != codes.NotFound:
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "failed to wait for the receipt of action %s", actionHash)
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReceiptsByBlock returns the receipts of the actions in the block at height, in the order of the actions in the
+// block. The block and its receipts are read by a single GetRawBlocks call.
+func ReceiptsByBlock(ctx context.Context, c iotexapi.APIServiceClient, height uint64) ([]*iotextypes.Receipt, error) {
+	res, err := c.GetRawBlocks(ctx, &iotexapi.GetRawBlocksRequest{
+		StartHeight:  height,
+		Count:        1,
+		WithReceipts: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block %d", height)
+	}
+	if len(res.GetBlocks()) != 1 {
+		return nil, errors.Errorf("expect 1 block at height %d, got %d", height, len(res.GetBlocks()))
+	}
+	info := res.GetBlocks()[0]
+	if h := info.GetBlock().GetHeader().GetCore().GetHeight(); h != height {
+		return nil, errors.Errorf("expect block at height %d, got %d", height, h)
+	}
+	receipts := info.GetReceipts()
+	if numActs := len(info.GetBlock().GetBody().GetActions()); len(receipts) != numActs {
+		return nil, errors.Errorf("block %d has %d actions, but %d receipts", height, numActs, len(receipts))
+	}
+	return receipts, nil
+}
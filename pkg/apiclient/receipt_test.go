@@ -0,0 +1,113 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWaitForReceipt(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	notFound := status.Error(codes.NotFound, "not found")
+
+	t.Run("keep waiting on not found", func(t *testing.T) {
+		gomock.InOrder(
+			c.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(nil, notFound).Times(2),
+			c.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(&iotexapi.GetReceiptByActionResponse{
+				ReceiptInfo: &iotexapi.ReceiptInfo{
+					Receipt: &iotextypes.Receipt{Status: 1, BlkHeight: 10},
+				},
+			}, nil),
+		)
+		receipt, err := WaitForReceipt(ctx, c, "abcd", time.Millisecond)
+		require.NoError(err)
+		require.EqualValues(10, receipt.BlkHeight)
+	})
+
+	t.Run("other error is fatal", func(t *testing.T) {
+		c.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "bad hash"))
+		_, err := WaitForReceipt(ctx, c, "abcd", time.Millisecond)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("context expires", func(t *testing.T) {
+		c.EXPECT().GetReceiptByAction(gomock.Any(), gomock.Any()).Return(nil, notFound).AnyTimes()
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, err := WaitForReceipt(ctx, c, "abcd", time.Millisecond)
+		require.True(errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		_, err := WaitForReceipt(ctx, c, "abcd", 0)
+		require.Error(err)
+	})
+}
+
+func TestReceiptsByBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	block := func(height uint64, numActs int) *iotextypes.Block {
+		return &iotextypes.Block{
+			Header: &iotextypes.BlockHeader{Core: &iotextypes.BlockHeaderCore{Height: height}},
+			Body:   &iotextypes.BlockBody{Actions: make([]*iotextypes.Action, numActs)},
+		}
+	}
+	receipts := []*iotextypes.Receipt{
+		{ActHash: []byte{1}, BlkHeight: 10},
+		{ActHash: []byte{2}, BlkHeight: 10},
+		{ActHash: []byte{3}, BlkHeight: 10},
+	}
+
+	c.EXPECT().GetRawBlocks(gomock.Any(), &iotexapi.GetRawBlocksRequest{
+		StartHeight:  10,
+		Count:        1,
+		WithReceipts: true,
+	}).Return(&iotexapi.GetRawBlocksResponse{
+		Blocks: []*iotexapi.BlockInfo{{Block: block(10, 3), Receipts: receipts}},
+	}, nil)
+	res, err := ReceiptsByBlock(ctx, c, 10)
+	require.NoError(err)
+	require.Equal(receipts, res)
+
+	for _, v := range []struct {
+		res *iotexapi.GetRawBlocksResponse
+		err error
+	}{
+		{nil, errors.New("unavailable")},
+		{&iotexapi.GetRawBlocksResponse{}, nil},
+		{&iotexapi.GetRawBlocksResponse{
+			Blocks: []*iotexapi.BlockInfo{{Block: block(11, 3), Receipts: receipts}},
+		}, nil},
+		{&iotexapi.GetRawBlocksResponse{
+			Blocks: []*iotexapi.BlockInfo{{Block: block(10, 4), Receipts: receipts}},
+		}, nil},
+	} {
+		c.EXPECT().GetRawBlocks(gomock.Any(), gomock.Any()).Return(v.res, v.err)
+		_, err = ReceiptsByBlock(ctx, c, 10)
+		require.Error(err)
+	}
+}
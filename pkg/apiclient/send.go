@@ -0,0 +1,44 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// SendActionOnce sends the signed action unless it has been sent successfully before, and returns the action hash.
+// Successfully sent actions are recorded in seen, keyed by the hash of the serialized action, which is the action hash
+// for protobuf-encoded actions. Sending a recorded action again is a no-op returning the known hash, so that a retry
+// after a timeout never submits the same action twice.
+func SendActionOnce(ctx context.Context, c iotexapi.APIServiceClient, act *iotextypes.Action, seen *sync.Map) (string, error) {
+	data, err := proto.Marshal(act)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to serialize action")
+	}
+	h := hash.Hash256b(data)
+	key := hex.EncodeToString(h[:])
+	if actHash, ok := seen.Load(key); ok {
+		return actHash.(string), nil
+	}
+	res, err := c.SendAction(ctx, &iotexapi.SendActionRequest{Action: act})
+	if err != nil {
+		return "", err
+	}
+	actHash := res.GetActionHash()
+	if actHash == "" {
+		actHash = key
+	}
+	seen.Store(key, actHash)
+	return actHash, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSendActionOnce(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	tsf, err := action.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(27), 1, big.NewInt(1), nil, 10000, big.NewInt(1))
+	require.NoError(err)
+	h, err := tsf.Hash()
+	require.NoError(err)
+	expected := hex.EncodeToString(h[:])
+
+	var seen sync.Map
+	gomock.InOrder(
+		c.EXPECT().SendAction(gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout")),
+		c.EXPECT().SendAction(gomock.Any(), gomock.Any()).Return(&iotexapi.SendActionResponse{ActionHash: expected}, nil).Times(1),
+	)
+	_, err = SendActionOnce(ctx, c, tsf.Proto(), &seen)
+	require.Error(err)
+	for i := 0; i < 3; i++ {
+		actHash, err := SendActionOnce(ctx, c, tsf.Proto(), &seen)
+		require.NoError(err)
+		require.Equal(expected, actHash)
+	}
+	// the key of a protobuf-encoded action is its hash
+	_, ok := seen.Load(expected)
+	require.True(ok)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+)
+
+// _sgdRegistryViewABI is the view of the system sgd registry contract listing the registered contracts
+const _sgdRegistryViewABI = `[{"inputs":[],"name":"getContracts","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"}]`
+
+// SGDRegisteredContracts reads the list of contracts registered in the system sgd registry contract at sgdAddr, e.g.,
+// genesis.Poll.SystemSGDAddr()
+func SGDRegisteredContracts(ctx context.Context, c ServiceClient, sgdAddr address.Address) ([]address.Address, error) {
+	if sgdAddr == nil {
+		return nil, errors.New("system sgd contract is not configured")
+	}
+	values, err := CallContract(ctx, c, sgdAddr.String(), _sgdRegistryViewABI, "getContracts")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read sgd registered contracts")
+	}
+	ethAddrs, ok := values[0].([]common.Address)
+	if !ok {
+		return nil, errors.Errorf("unexpected type %T of sgd registered contracts", values[0])
+	}
+	addrs := make([]address.Address, 0, len(ethAddrs))
+	for _, ethAddr := range ethAddrs {
+		addr, err := address.FromBytes(ethAddr.Bytes())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid sgd registered contract %s", ethAddr.Hex())
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSGDRegisteredContracts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	sgdAddr := identityset.Address(10)
+	sgdABI, err := abi.JSON(strings.NewReader(_sgdRegistryViewABI))
+	require.NoError(err)
+	ret, err := sgdABI.Methods["getContracts"].Outputs.Pack([]common.Address{
+		common.BytesToAddress(identityset.Address(1).Bytes()),
+		common.BytesToAddress(identityset.Address(2).Bytes()),
+	})
+	require.NoError(err)
+
+	c.EXPECT().ReadContract(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *iotexapi.ReadContractRequest, _ ...grpc.CallOption) (*iotexapi.ReadContractResponse, error) {
+			require.Equal(sgdAddr.String(), in.GetExecution().GetContract())
+			require.Equal(sgdABI.Methods["getContracts"].ID, in.GetExecution().GetData())
+			return &iotexapi.ReadContractResponse{Data: hex.EncodeToString(ret)}, nil
+		})
+	addrs, err := SGDRegisteredContracts(ctx, c, sgdAddr)
+	require.NoError(err)
+	require.Len(addrs, 2)
+	require.Equal(identityset.Address(1).String(), addrs[0].String())
+	require.Equal(identityset.Address(2).String(), addrs[1].String())
+
+	_, err = SGDRegisteredContracts(ctx, c, nil)
+	require.ErrorContains(err, "system sgd contract is not configured")
+
+	c.EXPECT().ReadContract(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, err = SGDRegisteredContracts(ctx, c, sgdAddr)
+	require.ErrorContains(err, "unavailable")
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+)
+
+// SyncLag returns how far the timestamp of the tip block falls behind the current time. The lag is zero if the tip
+// timestamp is ahead of the local clock.
+func SyncLag(ctx context.Context, c ServiceClient) (time.Duration, error) {
+	meta, err := c.GetChainMeta(ctx, &iotexapi.GetChainMetaRequest{})
+	if err != nil {
+		return 0, err
+	}
+	tip := meta.GetChainMeta().GetHeight()
+	res, err := c.GetBlockMetas(ctx, &iotexapi.GetBlockMetasRequest{
+		Lookup: &iotexapi.GetBlockMetasRequest_ByIndex{
+			ByIndex: &iotexapi.GetBlockMetasByIndexRequest{Start: tip, Count: 1},
+		},
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get the meta of tip block %d", tip)
+	}
+	if len(res.GetBlkMetas()) == 0 {
+		return 0, errors.Errorf("meta of tip block %d is not available", tip)
+	}
+	ts := res.GetBlkMetas()[0].GetTimestamp()
+	if err := ts.CheckValid(); err != nil {
+		return 0, errors.Wrapf(err, "invalid timestamp of tip block %d", tip)
+	}
+	lag := time.Since(ts.AsTime())
+	if lag < 0 {
+		lag = 0
+	}
+	return lag, nil
+}
+
+// IsSynced returns whether the tip block falls behind the current time by no more than threshold. Any error in
+// reading the tip is treated as not synced.
+func IsSynced(ctx context.Context, c ServiceClient, threshold time.Duration) bool {
+	lag, err := SyncLag(ctx, c)
+	return err == nil && lag <= threshold
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSyncLag(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	expectTip := func(ts time.Time) {
+		c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+			ChainMeta: &iotextypes.ChainMeta{Height: 100},
+		}, nil)
+		c.EXPECT().GetBlockMetas(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, in *iotexapi.GetBlockMetasRequest, _ ...grpc.CallOption) (*iotexapi.GetBlockMetasResponse, error) {
+				require.EqualValues(100, in.GetByIndex().GetStart())
+				require.EqualValues(1, in.GetByIndex().GetCount())
+				return &iotexapi.GetBlockMetasResponse{
+					BlkMetas: []*iotextypes.BlockMeta{{Height: 100, Timestamp: timestamppb.New(ts)}},
+				}, nil
+			})
+	}
+
+	expectTip(time.Now().Add(-time.Minute))
+	lag, err := SyncLag(ctx, c)
+	require.NoError(err)
+	require.True(lag >= time.Minute && lag < 2*time.Minute)
+
+	// tip ahead of local clock
+	expectTip(time.Now().Add(time.Minute))
+	lag, err = SyncLag(ctx, c)
+	require.NoError(err)
+	require.Zero(lag)
+
+	expectTip(time.Now().Add(-time.Minute))
+	require.True(IsSynced(ctx, c, 2*time.Minute))
+	expectTip(time.Now().Add(-time.Hour))
+	require.False(IsSynced(ctx, c, 2*time.Minute))
+
+	c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, err = SyncLag(ctx, c)
+	require.Error(err)
+	c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	require.False(IsSynced(ctx, c, time.Hour))
+
+	c.EXPECT().GetChainMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetChainMetaResponse{
+		ChainMeta: &iotextypes.ChainMeta{Height: 100},
+	}, nil)
+	c.EXPECT().GetBlockMetas(gomock.Any(), gomock.Any()).Return(&iotexapi.GetBlockMetasResponse{}, nil)
+	_, err = SyncLag(ctx, c)
+	require.Error(err)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+)
+
+// ErrIncompatibleServer is the error that the server is older than the required version
+var ErrIncompatibleServer = errors.New("incompatible server version")
+
+// CheckServerCompatible reads the package version of the server from GetServerMeta, and returns ErrIncompatibleServer
+// if it is older than minVersion. Versions are compared by major, minor and patch numbers, e.g., v1.11.0, while the
+// pre-release or build suffix, e.g., -rc1 or the -12-gabcdef of git describe, is ignored.
+func CheckServerCompatible(ctx context.Context, c ServiceClient, minVersion string) error {
+	required, err := parseVersion(minVersion)
+	if err != nil {
+		return errors.Wrap(err, "invalid min version")
+	}
+	res, err := c.GetServerMeta(ctx, &iotexapi.GetServerMetaRequest{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get server meta")
+	}
+	serverVersion := res.GetServerMeta().GetPackageVersion()
+	ver, err := parseVersion(serverVersion)
+	if err != nil {
+		return errors.Wrap(err, "invalid server version")
+	}
+	for i := range ver {
+		if ver[i] != required[i] {
+			if ver[i] < required[i] {
+				return errors.Wrapf(ErrIncompatibleServer, "server version %s is older than %s", serverVersion, minVersion)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// parseVersion returns the major, minor and patch numbers of a version like v1.2.3, where the minor and patch numbers
+// could be omitted as zero
+func parseVersion(s string) ([3]uint64, error) {
+	var ver [3]uint64
+	core := strings.TrimPrefix(s, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) > len(ver) {
+		return ver, errors.Errorf("malformed version %q", s)
+	}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return ver, errors.Errorf("malformed version %q", s)
+		}
+		ver[i] = n
+	}
+	return ver, nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package apiclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi/mock_iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckServerCompatible(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_iotexapi.NewMockAPIServiceClient(ctrl)
+	ctx := context.Background()
+	for _, v := range []struct {
+		server, min string
+		compatible  bool
+	}{
+		{"v1.11.0", "v1.11.0", true},
+		{"v1.11.2", "v1.11.0", true},
+		{"v1.12.0", "v1.11.3", true},
+		{"v2.0.0", "v1.11.3", true},
+		{"v1.11.0-12-g1a2b3c4", "v1.11.0", true},
+		{"v1.11.0-rc1", "1.11", true},
+		{"v1.10.9", "v1.11.0", false},
+		{"v1.11.0", "v1.11.1", false},
+		{"v0.9.0", "v1", false},
+	} {
+		c.EXPECT().GetServerMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetServerMetaResponse{
+			ServerMeta: &iotextypes.ServerMeta{PackageVersion: v.server},
+		}, nil)
+		err := CheckServerCompatible(ctx, c, v.min)
+		if v.compatible {
+			require.NoError(err, v.server)
+		} else {
+			require.ErrorIs(err, ErrIncompatibleServer, v.server)
+		}
+	}
+
+	// unparsable versions
+	require.ErrorContains(CheckServerCompatible(ctx, c, "latest"), "invalid min version")
+	c.EXPECT().GetServerMeta(gomock.Any(), gomock.Any()).Return(&iotexapi.GetServerMetaResponse{
+		ServerMeta: &iotextypes.ServerMeta{PackageVersion: "NoBuildInfo"},
+	}, nil)
+	require.ErrorContains(CheckServerCompatible(ctx, c, "v1.11.0"), "invalid server version")
+
+	c.EXPECT().GetServerMeta(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	require.ErrorContains(CheckServerCompatible(ctx, c, "v1.11.0"), "unavailable")
+}
@@ -31,3 +31,11 @@ func ConvertIotxToRau(iotx int64) *big.Int {
 	itx := big.NewInt(iotx)
 	return itx.Mul(itx, big.NewInt(1e18))
 }
+
+// ConvertRauToIotx converts a Rau amount to Iotx for display. The result is computed with enough precision to hold
+// all 18 decimals of the amount, the canonical amount should remain in Rau
+func ConvertRauToIotx(rau *big.Int) *big.Float {
+	prec := uint(rau.BitLen()) + 128
+	iotx := new(big.Float).SetPrec(prec).SetInt(rau)
+	return iotx.Quo(iotx, new(big.Float).SetPrec(prec).SetInt64(Iotx))
+}
@@ -8,6 +8,7 @@ package addrutil
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
 )
 
 // IoAddrToEvmAddr converts IoTeX address into evm address
@@ -18,3 +19,16 @@ func IoAddrToEvmAddr(ioAddr string) (common.Address, error) {
 	}
 	return common.BytesToAddress(address.Bytes()), nil
 }
+
+// NormalizeAddress returns the canonical io1 string of the address, which could be either in the canonical form or in
+// the legacy form tolerated before the Newfoundland fork
+func NormalizeAddress(s string) (string, error) {
+	if addr, err := address.FromString(s); err == nil {
+		return addr.String(), nil
+	}
+	addr, err := address.FromStringLegacy(s)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid address %s", s)
+	}
+	return addr.String(), nil
+}
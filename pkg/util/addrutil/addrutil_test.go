@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address/bech32"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/test/identityset"
@@ -30,3 +31,30 @@ func TestIoAddrToEvmAddr(t *testing.T) {
 		require.Contains(t, err.Error(), "address length = 0, expecting 41")
 	})
 }
+
+// legacyAddress encodes the address bytes prefixed with extra bytes, which is tolerated before Newfoundland
+func legacyAddress(t *testing.T, b []byte) string {
+	grouped, err := bech32.ConvertBits(append([]byte{0}, b...), 8, 5, true)
+	require.NoError(t, err)
+	s, err := bech32.Encode("io", grouped)
+	require.NoError(t, err)
+	return s
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	require := require.New(t)
+	addr := identityset.Address(28)
+
+	s, err := NormalizeAddress(addr.String())
+	require.NoError(err)
+	require.Equal(addr.String(), s)
+
+	legacy := legacyAddress(t, addr.Bytes())
+	require.NotEqual(addr.String(), legacy)
+	s, err = NormalizeAddress(legacy)
+	require.NoError(err)
+	require.Equal(addr.String(), s)
+
+	_, err = NormalizeAddress("0x1234")
+	require.Error(err)
+}
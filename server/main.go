@@ -116,6 +116,9 @@ func main() {
 	}
 
 	cfg.Genesis = genesisCfg
+	if err = config.SyncChainID(&cfg); err != nil {
+		glog.Fatalln("Failed to sync chain ID.", zap.Error(err))
+	}
 	cfgToLog := cfg
 	cfgToLog.Chain.ProducerPrivKey = ""
 	cfgToLog.Network.MasterKey = ""
@@ -123,6 +126,7 @@ func main() {
 	log.S().Infof("EVM Network ID: %d, Chain ID: %d", cfg.Chain.EVMNetworkID, cfg.Chain.ID)
 	log.S().Infof("Genesis timestamp: %d", genesisCfg.Timestamp)
 	log.S().Infof("Genesis hash: %x", block.GenesisHash())
+	log.L().Info("Genesis in use.", cfg.Genesis.LogFields()...)
 
 	// liveness start
 	probeSvr := probe.New(cfg.System.HTTPStatsPort)
@@ -140,6 +140,11 @@ func (st *Account) AccountType() int32 {
 	return st.accountType
 }
 
+// IsLegacy returns true if the account is of the legacy type, whose nonce starts from 1
+func (st *Account) IsLegacy() bool {
+	return st.accountType == 0
+}
+
 // SetPendingNonce sets the pending nonce
 func (st *Account) SetPendingNonce(nonce uint64) error {
 	switch st.accountType {
@@ -21,6 +21,7 @@ func TestNonce(t *testing.T) {
 	t.Run("legacy account type", func(t *testing.T) {
 		acct, err := NewAccount(LegacyNonceAccountTypeOption())
 		require.NoError(err)
+		require.True(acct.IsLegacy())
 		require.Equal(uint64(1), acct.PendingNonce())
 		require.Error(acct.SetPendingNonce(0))
 		require.Error(acct.SetPendingNonce(1))
@@ -31,6 +32,7 @@ func TestNonce(t *testing.T) {
 	t.Run("zero nonce account type", func(t *testing.T) {
 		acct, err := NewAccount()
 		require.NoError(err)
+		require.False(acct.IsLegacy())
 		require.Equal(uint64(0), acct.PendingNonce())
 		require.Error(acct.SetPendingNonce(2))
 		require.NoError(acct.SetPendingNonce(1))
@@ -7,6 +7,7 @@ package state
 
 import (
 	"math/big"
+	"sort"
 	"strings"
 
 	"github.com/iotexproject/go-pkgs/hash"
@@ -34,10 +35,11 @@ type (
 		Address       string
 		Votes         *big.Int
 		RewardAddress string
-		CanName       []byte // used as identifier to merge with native staking result, not part of protobuf
+		CanName       []byte   // used as identifier to merge with native staking result, not part of protobuf
+		SelfStake     *big.Int // self-staked amount of native staking candidate, not part of protobuf
 	}
 
-	// CandidateList indicates the list of Candidates which is sortable
+	// CandidateList indicates the list of Candidates which is sortable in the canonical order, see SortCandidates
 	CandidateList []*Candidate
 
 	// CandidateMap is a map of Candidates using Hash160 as key
@@ -64,11 +66,16 @@ func (c *Candidate) Clone() *Candidate {
 	}
 	name := make([]byte, len(c.CanName))
 	copy(name, c.CanName)
+	var selfStake *big.Int
+	if c.SelfStake != nil {
+		selfStake = new(big.Int).Set(c.SelfStake)
+	}
 	return &Candidate{
 		Address:       c.Address,
 		Votes:         new(big.Int).Set(c.Votes),
 		RewardAddress: c.RewardAddress,
 		CanName:       name,
+		SelfStake:     selfStake,
 	}
 }
 
@@ -102,6 +109,18 @@ func (l CandidateList) Less(i, j int) bool {
 	return strings.Compare(l[i].Address, l[j].Address) == 1
 }
 
+// Page returns at most limit candidates of the list starting from offset
+func (l CandidateList) Page(offset, limit uint32) CandidateList {
+	if uint64(offset) >= uint64(len(l)) {
+		return CandidateList{}
+	}
+	end := uint64(offset) + uint64(limit)
+	if end > uint64(len(l)) {
+		end = uint64(len(l))
+	}
+	return l[offset:end]
+}
+
 // Serialize serializes a list of Candidates to bytes
 func (l *CandidateList) Serialize() ([]byte, error) {
 	return proto.Marshal(l.Proto())
@@ -167,12 +186,33 @@ func pbToCandidate(candPb *iotextypes.Candidate) (*Candidate, error) {
 	return candidate, nil
 }
 
-// MapToCandidates converts a map of cachedCandidates to candidate list
+// FilterEligibleCandidates returns the candidates whose self-stake is no less than minSelfStake, in the original order.
+// Candidates without self-stake information are not eligible. The input list is not modified.
+func FilterEligibleCandidates(cands []*Candidate, minSelfStake *big.Int) []*Candidate {
+	eligible := make([]*Candidate, 0, len(cands))
+	for _, cand := range cands {
+		if cand == nil || cand.SelfStake == nil || cand.SelfStake.Cmp(minSelfStake) < 0 {
+			continue
+		}
+		eligible = append(eligible, cand)
+	}
+	return eligible
+}
+
+// SortCandidates sorts the candidates in the canonical order, which is descending by votes, tie-broken by address in
+// descending lexicographical order. All the nodes must rank the candidates identically, so any ranking of candidates
+// should be based on this order rather than the order they are read in.
+func SortCandidates(cands []*Candidate) {
+	sort.Sort(CandidateList(cands))
+}
+
+// MapToCandidates converts a map of cachedCandidates to candidate list sorted in the canonical order
 func MapToCandidates(candidateMap CandidateMap) (CandidateList, error) {
 	candidates := make(CandidateList, 0, len(candidateMap))
 	for _, cand := range candidateMap {
 		candidates = append(candidates, cand)
 	}
+	SortCandidates(candidates)
 	return candidates, nil
 }
 
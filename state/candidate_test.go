@@ -6,8 +6,8 @@
 package state
 
 import (
+	"math"
 	"math/big"
-	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -62,6 +62,68 @@ func TestCandidateClone(t *testing.T) {
 		Votes:   big.NewInt(2),
 	}
 	r.True(cand1.Equal(cand1.Clone()))
+	cand1.SelfStake = big.NewInt(1)
+	cand2 := cand1.Clone()
+	r.Equal(cand1.SelfStake, cand2.SelfStake)
+	cand2.SelfStake.SetInt64(2)
+	r.Equal(big.NewInt(1), cand1.SelfStake)
+}
+
+func TestFilterEligibleCandidates(t *testing.T) {
+	r := require.New(t)
+	cands := []*Candidate{
+		{Address: identityset.Address(1).String(), Votes: big.NewInt(1), SelfStake: big.NewInt(100)},
+		{Address: identityset.Address(2).String(), Votes: big.NewInt(2), SelfStake: big.NewInt(99)},
+		{Address: identityset.Address(3).String(), Votes: big.NewInt(3)},
+		{Address: identityset.Address(4).String(), Votes: big.NewInt(4), SelfStake: big.NewInt(101)},
+	}
+	eligible := FilterEligibleCandidates(cands, big.NewInt(100))
+	r.Len(eligible, 2)
+	r.Equal(cands[0], eligible[0])
+	r.Equal(cands[3], eligible[1])
+	r.Len(cands, 4)
+	r.Equal(identityset.Address(2).String(), cands[1].Address)
+	r.Empty(FilterEligibleCandidates(nil, big.NewInt(100)))
+}
+
+func TestSortCandidates(t *testing.T) {
+	r := require.New(t)
+	a, b, c := identityset.Address(1).String(), identityset.Address(2).String(), identityset.Address(3).String()
+	if a < b {
+		a, b = b, a
+	}
+	cands := []*Candidate{
+		{Address: c, Votes: big.NewInt(1)},
+		{Address: b, Votes: big.NewInt(2)},
+		{Address: a, Votes: big.NewInt(2)},
+	}
+	SortCandidates(cands)
+	// descending by votes, then by address
+	r.Equal(a, cands[0].Address)
+	r.Equal(b, cands[1].Address)
+	r.Equal(c, cands[2].Address)
+}
+
+func TestCandidateListPage(t *testing.T) {
+	r := require.New(t)
+	l := CandidateList{
+		{Address: identityset.Address(1).String(), Votes: big.NewInt(3)},
+		{Address: identityset.Address(2).String(), Votes: big.NewInt(2)},
+		{Address: identityset.Address(3).String(), Votes: big.NewInt(1)},
+	}
+	for _, v := range []struct {
+		offset, limit uint32
+		expected      CandidateList
+	}{
+		{0, 2, l[:2]},
+		{1, 2, l[1:]},
+		{2, 10, l[2:]},
+		{0, 0, CandidateList{}},
+		{3, 1, CandidateList{}},
+		{math.MaxUint32, math.MaxUint32, CandidateList{}},
+	} {
+		r.Equal(v.expected, l.Page(v.offset, v.limit))
+	}
 }
 
 func TestCandidateListSerializeAndDeserialize(t *testing.T) {
@@ -120,7 +182,6 @@ func TestCandidate(t *testing.T) {
 	candidateList, err := MapToCandidates(candidateMap)
 	require.NoError(err)
 	require.Equal(3, len(candidateList))
-	sort.Sort(candidateList)
 
 	require.Equal(identityset.Address(30).String(), candidateList[0].Address)
 	require.Equal(identityset.Address(29).String(), candidateList[1].Address)
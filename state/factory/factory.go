@@ -8,6 +8,7 @@ package factory
 import (
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 	"sync"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/db/batch"
 	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/mptrie"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
@@ -55,8 +57,8 @@ const (
 var (
 	// ErrNotSupported is the error that the statedb is not for archive mode
 	ErrNotSupported = errors.New("not supported")
-	// ErrNoArchiveData is the error that the node have no archive data
-	ErrNoArchiveData = errors.New("no archive data")
+	// ErrNoArchiveData is the error that the node have no archive data, which is state.ErrHeightNotRetained
+	ErrNoArchiveData = state.ErrHeightNotRetained
 
 	_dbBatchSizelMtc = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -69,7 +71,7 @@ var (
 	//DefaultConfig is the default config for state factory
 	DefaultConfig = Config{
 		Chain:   blockchain.DefaultConfig,
-		Genesis: genesis.Default,
+		Genesis: genesis.GetDefault(),
 	}
 )
 
@@ -83,15 +85,99 @@ type (
 		lifecycle.StartStopper
 		protocol.StateReader
 		Register(protocol.Protocol) error
+		// RegisteredProtocols returns the IDs of the protocols registered to the factory
+		RegisteredProtocols() []string
 		Validate(context.Context, *block.Block) error
+		// RunBlock runs the block at the next height in a working set without committing it, and returns the digest
+		// of the state changes and the receipts, which are not verified against the block header. It allows a
+		// validator to compare its own results with the ones claimed by the block producer.
+		RunBlock(context.Context, *block.Block) (hash.Hash256, []*action.Receipt, error)
 		// NewBlockBuilder creates block builder
 		NewBlockBuilder(context.Context, actpool.ActPool, func(action.Envelope) (action.SealedEnvelope, error)) (*block.Builder, error)
 		SimulateExecution(context.Context, address.Address, *action.Execution) ([]byte, *action.Receipt, error)
 		ReadContractStorage(context.Context, address.Address, []byte) ([]byte, error)
+		// PutBlock applies the block at the next height, i.e., runs all the actions of the block, including
+		// executions and staking actions, through the registered protocols and commits the state changes
 		PutBlock(context.Context, *block.Block) error
+		// CommitBlock applies the block as PutBlock does, and returns the root hash of the state trie right after the
+		// commit along with the receipts, so that the root is not changed by another commit in between. The root is
+		// hash.ZeroHash256 if the factory keeps no state trie, i.e., the stateDB.
+		CommitBlock(context.Context, *block.Block) (hash.Hash256, []*action.Receipt, error)
+		// RunActions applies the actions atomically on top of the current states at the height, which must be the
+		// next height, and returns the receipts. The state changes are committed as the ones of a block at the height
+		// if all the actions succeed, and rolled back on any failure. It serves the simulators applying actions
+		// without blocks, so a block at the same height can't be put afterwards.
+		RunActions(context.Context, uint64, []action.SealedEnvelope) ([]*action.Receipt, error)
+		// RunActionsWithRoot applies the actions as RunActions does, and returns the root hash of the state trie
+		// right after the commit along with the receipts, so that the root is not changed by another commit in
+		// between. The root is hash.ZeroHash256 if the factory keeps no state trie, i.e., the stateDB.
+		RunActionsWithRoot(context.Context, uint64, []action.SealedEnvelope) (hash.Hash256, []*action.Receipt, error)
 		DeleteTipBlock(context.Context, *block.Block) error
+		// StateAtHeight reads the state at the height in archive mode. The error wraps state.ErrStateNotExist if the
+		// state doesn't exist at the height, or state.ErrHeightNotRetained if the states at the height are not kept.
+		// Reading a state at the current height by State returns an error wrapping state.ErrStateNotExist as well if
+		// the state doesn't exist, so callers could tell a missing state from a db failure by errors.Is.
 		StateAtHeight(uint64, interface{}, ...protocol.StateOption) error
 		StatesAtHeight(uint64, ...protocol.StateOption) (state.Iterator, error)
+		// ExportState writes the states at height to the writer as a stream of varint length-prefixed
+		// snapshotpb.StateEntry messages. The states at a height other than the tip height are exported from the
+		// historical state trie, which is kept by the factory in archive mode only.
+		ExportState(context.Context, uint64, io.Writer) error
+		// ImportState imports the states exported by ExportState into a fresh factory
+		ImportState(context.Context, io.Reader) error
+		// Checkpoint stores a copy of the states of all namespaces at the current height under the name, which could
+		// be restored by RestoreCheckpoint to roll back to a known-good state, e.g., after a bad upgrade. The states
+		// are copied key by key within the underlying DB, which must list its buckets, e.g., the bolt DB.
+		Checkpoint(name string) error
+		// RestoreCheckpoint replaces the states of all namespaces with the ones stored under the name by Checkpoint,
+		// and restarts the protocols at the height of the checkpoint. A restore interrupted by a crash is resumed when
+		// the factory starts next. The block DAO is not rewound, the blocks after the checkpoint are replayed to the
+		// factory once the block DAO starts, as it does for any indexer lagging behind. The error wraps
+		// state.ErrStateNotExist if there is no such checkpoint.
+		RestoreCheckpoint(name string) error
+		// Proof returns the proof of the state of key in namespace ns against the current state root, which is the
+		// serialized trie nodes from the leaf of the state up to the root. It could be verified by VerifyProof.
+		Proof(ns string, key []byte) ([][]byte, error)
+		// Nonces returns the committed nonce, i.e., the number of actions of the account committed to the chain, and
+		// the pending nonce, i.e., the nonce of the next action of the account, at the current height. Both are zero
+		// for an account not recorded on the chain yet. Actions queued in the actpool are not counted.
+		Nonces(addr string) (committed uint64, pending uint64, err error)
+		// AccountState returns the account of the address at the current height. The error wraps
+		// state.ErrStateNotExist if the account is not recorded on the chain yet.
+		AccountState(addr string) (*state.Account, error)
+		// AccountStateAtHeight returns the account of the address at the height in archive mode. The error wraps
+		// state.ErrStateNotExist if the account doesn't exist at the height.
+		AccountStateAtHeight(height uint64, addr string) (*state.Account, error)
+		// CandidateByName returns the staking candidate of name at the current height, which is looked up by the
+		// index of the staking view rather than scanning all candidates. The error wraps state.ErrStateNotExist if
+		// there is no such candidate.
+		CandidateByName(name string) (*state.Candidate, error)
+		// CandidateByAddress returns the staking candidate owned by the address at the current height as
+		// CandidateByName does
+		CandidateByAddress(addr string) (*state.Candidate, error)
+		// PutState writes the state of key in namespace ns along with the next block, which allows a protocol out of
+		// the tree to persist its own states. The state is pending until the block at the next height is committed,
+		// and is covered by the state root of that block. The namespaces of the protocols in the tree, e.g., the
+		// accounts, and the ones internal to the factory, i.e., the state trie and the archive, are not writable.
+		PutState(ns string, key []byte, s state.Serializer) error
+		// GetState reads the state of key in namespace ns at the current height, which doesn't cover the pending
+		// states of PutState. The error wraps state.ErrStateNotExist if the key doesn't exist.
+		GetState(ns string, key []byte, s state.Deserializer) error
+		// IterateStates calls fn on each account at the current height whose key starts with prefix, in the key
+		// order, and stops on the first error returned by fn. The key is the hash160 of the account address.
+		IterateStates(prefix []byte, fn func(key []byte, s *state.Account) error) error
+		// GetCodeByHash returns the contract code of the code hash, which could be shared by several contracts. The
+		// error wraps state.ErrStateNotExist if no contract has deployed the code.
+		GetCodeByHash(codeHash hash.Hash256) ([]byte, error)
+		// WorkingSetAtHeight returns a working set to run the block at height on top of the states at height-1, which
+		// allows replaying a historical block. It requires the archive mode, and returns state.ErrHeightNotRetained if
+		// the states at height-1 are not kept. The protocol views are rebuilt on the states at height-1, and
+		// ErrNotSupported is returned if a protocol cannot be started on them. The working set is never committed.
+		WorkingSetAtHeight(height uint64) (protocol.WorkingSet, error)
+		// StateRoots returns the state roots at the heights in archive mode, which are read in one pass. It returns
+		// state.ErrHeightNotRetained if the states at any of the heights are not kept, including a height above the
+		// tip height.
+		StateRoots(heights []uint64) (map[uint64]hash.Hash256, error)
 	}
 
 	// factory implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
@@ -109,6 +195,7 @@ type (
 		protocolView             protocol.View
 		skipBlockValidationOnPut bool
 		ps                       *patchStore
+		pendingStates            []*patch // states put by PutState, pending for the next block
 	}
 
 	// Config contains the config for factory
@@ -191,6 +278,9 @@ func (sf *factory) Start(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if err := resumeCheckpointRestore(sf.dao); err != nil {
+		return err
+	}
 	if sf.twoLayerTrie, err = newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, ArchiveTrieRootKey, true); err != nil {
 		return errors.Wrap(err, "failed to generate accountTrie from config")
 	}
@@ -259,12 +349,7 @@ func (sf *factory) newWorkingSet(ctx context.Context, height uint64) (*workingSe
 	span.AddEvent("factory.newWorkingSet")
 	defer span.End()
 
-	g := genesis.MustExtractGenesisContext(ctx)
-	flusher, err := db.NewKVStoreFlusher(
-		sf.dao,
-		batch.NewCachedBatch(),
-		sf.flusherOptions(!g.IsEaster(height))...,
-	)
+	flusher, err := sf.newFlusher(ctx, height)
 	if err != nil {
 		return nil, err
 	}
@@ -272,6 +357,77 @@ func (sf *factory) newWorkingSet(ctx context.Context, height uint64) (*workingSe
 	if err != nil {
 		return nil, err
 	}
+	ws, err := sf.startWorkingSet(ctx, height, store)
+	if err != nil {
+		return nil, err
+	}
+	if height == sf.currentChainHeight+1 {
+		if err := ws.applyPendingStates(sf.pendingStates); err != nil {
+			return nil, err
+		}
+	}
+	return ws, nil
+}
+
+// newWorkingSetAtHeight creates a working set to run the block at height on top of the archived states at height-1
+func (sf *factory) newWorkingSetAtHeight(ctx context.Context, height uint64) (*workingSet, error) {
+	view, err := sf.viewAtHeight(ctx, height-1)
+	if err != nil {
+		return nil, err
+	}
+	flusher, err := sf.newFlusher(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	store, err := newFactoryWorkingSetStoreAtHeight(view, flusher, height-1)
+	if err != nil {
+		return nil, err
+	}
+	return sf.startWorkingSet(ctx, height, store)
+}
+
+// viewAtHeight builds the protocol views by starting the protocols on the archived states at height, because the
+// views of the tip, e.g., the staking candidates and buckets, don't match the states at a historical height
+func (sf *factory) viewAtHeight(ctx context.Context, height uint64) (protocol.View, error) {
+	flusher, err := sf.newFlusher(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	store, err := newFactoryWorkingSetStoreAtHeight(protocol.View{}, flusher, height)
+	if err != nil {
+		if errors.Cause(err) == trie.ErrNotExist {
+			return nil, errors.Wrapf(state.ErrHeightNotRetained, "no archive trie for %d", height)
+		}
+		return nil, err
+	}
+	if err := store.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer store.Stop(ctx)
+
+	view, err := sf.registry.StartAll(
+		protocol.WithFeatureWithHeightCtx(protocol.WithRegistry(ctx, sf.registry)),
+		newWorkingSet(height, store),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(ErrNotSupported, "failed to start protocols on the states at height %d: %v", height, err)
+	}
+	if view == nil {
+		view = protocol.View{}
+	}
+	return view, nil
+}
+
+func (sf *factory) newFlusher(ctx context.Context, height uint64) (db.KVStoreFlusher, error) {
+	g := genesis.MustExtractGenesisContext(ctx)
+	return db.NewKVStoreFlusher(
+		sf.dao,
+		batch.NewCachedBatch(),
+		sf.flusherOptions(!g.IsEaster(height))...,
+	)
+}
+
+func (sf *factory) startWorkingSet(ctx context.Context, height uint64, store workingSetStore) (*workingSet, error) {
 	if err := store.Start(ctx); err != nil {
 		return nil, err
 	}
@@ -324,6 +480,10 @@ func (sf *factory) Register(p protocol.Protocol) error {
 	return p.Register(sf.registry)
 }
 
+func (sf *factory) RegisteredProtocols() []string {
+	return sf.registry.IDs()
+}
+
 func (sf *factory) Validate(ctx context.Context, blk *block.Block) error {
 	ctx = protocol.WithRegistry(ctx, sf.registry)
 	key := generateWorkingSetCacheKey(blk.Header, blk.Header.ProducerAddress())
@@ -345,6 +505,27 @@ func (sf *factory) Validate(ctx context.Context, blk *block.Block) error {
 	return nil
 }
 
+// RunBlock runs the block in a working set without committing it
+func (sf *factory) RunBlock(ctx context.Context, blk *block.Block) (hash.Hash256, []*action.Receipt, error) {
+	ctx = protocol.WithRegistry(ctx, sf.registry)
+	sf.mutex.RLock()
+	height := sf.currentChainHeight + 1
+	if blk.Height() != height {
+		sf.mutex.RUnlock()
+		return hash.ZeroHash256, nil, errors.Errorf("invalid block height %d, expecting %d", blk.Height(), height)
+	}
+	ws, err := sf.newWorkingSet(ctx, height)
+	sf.mutex.RUnlock()
+	if err != nil {
+		return hash.ZeroHash256, nil, errors.Wrap(err, "failed to obtain working set from state factory")
+	}
+	digest, err := ws.runBlock(ctx, blk)
+	if err != nil {
+		return hash.ZeroHash256, nil, errors.Wrap(err, "failed to run block with workingset in factory")
+	}
+	return digest, ws.receipts, nil
+}
+
 // NewBlockBuilder returns block builder which hasn't been signed yet
 func (sf *factory) NewBlockBuilder(
 	ctx context.Context,
@@ -412,15 +593,22 @@ func (sf *factory) ReadContractStorage(ctx context.Context, contract address.Add
 	return evm.ReadContractStorage(ctx, ws, contract, key)
 }
 
-// PutBlock persists all changes in RunActions() into the DB
+// PutBlock runs all the actions of the block through the registered protocols and commits the state changes into
+// the DB
 func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
+	_, _, err := sf.CommitBlock(ctx, blk)
+	return err
+}
+
+// CommitBlock commits the block as PutBlock does, and returns the state root after the commit along with the receipts
+func (sf *factory) CommitBlock(ctx context.Context, blk *block.Block) (hash.Hash256, []*action.Receipt, error) {
 	sf.mutex.Lock()
 	timer := sf.timerFactory.NewTimer("Commit")
 	sf.mutex.Unlock()
 	defer timer.End()
 	producer := blk.PublicKey().Address()
 	if producer == nil {
-		return errors.New("failed to get address")
+		return hash.ZeroHash256, nil, errors.New("failed to get address")
 	}
 	g := genesis.MustExtractGenesisContext(ctx)
 	ctx = protocol.WithBlockCtx(
@@ -436,7 +624,7 @@ func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 	key := generateWorkingSetCacheKey(blk.Header, blk.Header.ProducerAddress())
 	ws, isExist, err := sf.getFromWorkingSets(ctx, key)
 	if err != nil {
-		return err
+		return hash.ZeroHash256, nil, err
 	}
 	if !isExist {
 		// regenerate workingset
@@ -447,38 +635,95 @@ func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 		}
 		if err != nil {
 			log.L().Error("Failed to update state.", zap.Error(err))
-			return err
+			return hash.ZeroHash256, nil, err
 		}
 	}
 	sf.mutex.Lock()
 	defer sf.mutex.Unlock()
 	receipts, err := ws.Receipts()
 	if err != nil {
-		return err
+		return hash.ZeroHash256, nil, err
 	}
 	blk.Receipts = receipts
+	root, err := sf.commitWorkingSet(ctx, ws)
+	if err != nil {
+		return hash.ZeroHash256, nil, err
+	}
+	return root, receipts, nil
+}
+
+// commitWorkingSet commits the working set at the next height, and returns the state root after the commit. The
+// caller must hold the lock.
+func (sf *factory) commitWorkingSet(ctx context.Context, ws *workingSet) (hash.Hash256, error) {
 	h, _ := ws.Height()
 	if sf.currentChainHeight+1 != h {
 		// another working set with correct version already committed, do nothing
-		return fmt.Errorf(
+		return hash.ZeroHash256, fmt.Errorf(
 			"current state height %d + 1 doesn't match working set height %d",
 			sf.currentChainHeight, h,
 		)
 	}
 
 	if err := ws.Commit(ctx); err != nil {
-		return err
+		return hash.ZeroHash256, err
 	}
+	sf.pendingStates = sf.pendingStates[ws.pendingStates:]
 	rh, err := sf.dao.Get(ArchiveTrieNamespace, []byte(ArchiveTrieRootKey))
 	if err != nil {
-		return err
+		return hash.ZeroHash256, err
 	}
 	if err := sf.twoLayerTrie.SetRootHash(rh); err != nil {
-		return err
+		return hash.ZeroHash256, err
 	}
 	sf.currentChainHeight = h
+	return hash.BytesToHash256(rh), nil
+}
 
-	return nil
+// RunActions runs the actions at height in a working set, and commits it if all the actions succeed. The block
+// context at height is expected in ctx.
+func (sf *factory) RunActions(ctx context.Context, height uint64, acts []action.SealedEnvelope) ([]*action.Receipt, error) {
+	_, receipts, err := sf.RunActionsWithRoot(ctx, height, acts)
+	return receipts, err
+}
+
+// RunActionsWithRoot runs the actions as RunActions does, and returns the state root right after the commit along
+// with the receipts
+func (sf *factory) RunActionsWithRoot(ctx context.Context, height uint64, acts []action.SealedEnvelope) (hash.Hash256, []*action.Receipt, error) {
+	ws, err := sf.runActions(ctx, height, acts)
+	if err != nil {
+		return hash.ZeroHash256, nil, err
+	}
+	receipts, err := ws.Receipts()
+	if err != nil {
+		return hash.ZeroHash256, nil, err
+	}
+	sf.mutex.Lock()
+	defer sf.mutex.Unlock()
+	root, err := sf.commitWorkingSet(ctx, ws)
+	if err != nil {
+		return hash.ZeroHash256, nil, err
+	}
+	return root, receipts, nil
+}
+
+// runActions runs the actions in a working set at height, which must be the next height. The working set is
+// discarded on any failure, so none of the actions is committed.
+func (sf *factory) runActions(ctx context.Context, height uint64, acts []action.SealedEnvelope) (*workingSet, error) {
+	ctx = protocol.WithFeatureCtx(protocol.WithRegistry(ctx, sf.registry))
+	sf.mutex.RLock()
+	if sf.currentChainHeight+1 != height {
+		sf.mutex.RUnlock()
+		return nil, errors.Errorf("current state height %d + 1 doesn't match height %d", sf.currentChainHeight, height)
+	}
+	ws, err := sf.newWorkingSet(ctx, height)
+	sf.mutex.RUnlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain working set from state factory")
+	}
+	if err := ws.Process(ctx, acts); err != nil {
+		return nil, err
+	}
+	return ws, nil
 }
 
 func (sf *factory) DeleteTipBlock(_ context.Context, _ *block.Block) error {
@@ -553,6 +798,197 @@ func (sf *factory) States(opts ...protocol.StateOption) (uint64, state.Iterator,
 	return sf.currentChainHeight, state.NewIterator(values), nil
 }
 
+// ExportState writes the states at height to w. The states at a height other than the tip height are read from the
+// state trie archived at the height, which requires the archive mode.
+func (sf *factory) ExportState(ctx context.Context, height uint64, w io.Writer) error {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	if height == sf.currentChainHeight {
+		return exportState(w, func(ns string) ([][]byte, [][]byte, error) {
+			return kvStates(sf.dao, ns)
+		})
+	}
+	if !sf.saveHistory {
+		return errors.Wrapf(ErrNoArchiveData, "cannot export states at height %d other than tip height %d", height, sf.currentChainHeight)
+	}
+	if height > sf.currentChainHeight {
+		return errors.Wrapf(state.ErrHeightNotRetained, "query height %d is higher than tip height %d", height, sf.currentChainHeight)
+	}
+	tlt, err := archivedTrie(sf.dao, height)
+	if err != nil {
+		return err
+	}
+	defer tlt.Stop(ctx)
+	return exportState(w, func(ns string) ([][]byte, [][]byte, error) {
+		keys, values, _, err := trieStates(sf.dao, tlt, ns)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to export states at height %d", height)
+		}
+		if ns == AccountKVNamespace {
+			keys = append(keys, []byte(CurrentHeightKey))
+			values = append(values, byteutil.Uint64ToBytes(height))
+		}
+		return keys, values, nil
+	})
+}
+
+// ImportState is not supported, because the state trie cannot be rebuilt from the exported states
+func (sf *factory) ImportState(ctx context.Context, r io.Reader) error {
+	return errors.Wrap(ErrNotSupported, "factory cannot rebuild state trie from imported states")
+}
+
+// Checkpoint stores the states, including the state trie, at the current height under the name
+func (sf *factory) Checkpoint(name string) error {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	return checkpointState(sf.dao, name, sf.currentChainHeight)
+}
+
+// RestoreCheckpoint restores the states stored under the name, sets the state trie to the restored root, and restarts
+// the protocols with the restored states
+func (sf *factory) RestoreCheckpoint(name string) error {
+	sf.mutex.Lock()
+	if err := restoreCheckpoint(sf.dao, name); err != nil {
+		sf.mutex.Unlock()
+		return err
+	}
+	height, root, err := sf.restoredHeightAndRoot()
+	if err != nil {
+		sf.mutex.Unlock()
+		return err
+	}
+	if err := sf.twoLayerTrie.SetRootHash(root); err != nil {
+		sf.mutex.Unlock()
+		return err
+	}
+	sf.currentChainHeight = height
+	sf.workingsets.Clear()
+	sf.mutex.Unlock()
+
+	// protocols read states via the factory, so they are restarted without holding the lock
+	ctx := protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(
+		protocol.WithRegistry(context.Background(), sf.registry),
+		sf.cfg.Genesis,
+	))
+	view, err := sf.registry.StartAll(ctx, sf)
+	if err != nil {
+		return err
+	}
+	sf.mutex.Lock()
+	sf.protocolView = view
+	sf.mutex.Unlock()
+	return nil
+}
+
+func (sf *factory) restoredHeightAndRoot() (uint64, []byte, error) {
+	h, err := sf.dao.Get(AccountKVNamespace, []byte(CurrentHeightKey))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to get restored height")
+	}
+	root, err := sf.dao.Get(ArchiveTrieNamespace, []byte(ArchiveTrieRootKey))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to get restored state root")
+	}
+	return byteutil.BytesToUint64(h), root, nil
+}
+
+// Proof returns the proof of the state of key in namespace ns in the state trie
+func (sf *factory) Proof(ns string, key []byte) ([][]byte, error) {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, ArchiveTrieRootKey, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate state trie")
+	}
+	if err := tlt.Start(context.Background()); err != nil {
+		return nil, err
+	}
+	defer tlt.Stop(context.Background())
+
+	proof, err := tlt.Proof(namespaceKey(ns), toLegacyKey(key))
+	if errors.Cause(err) == trie.ErrNotExist {
+		return nil, errors.Wrapf(state.ErrStateNotExist, "failed to get proof of ns = %s and key = %x", ns, key)
+	}
+	return proof, err
+}
+
+// VerifyProof verifies that the state of key in namespace ns is value under the state root, given the proof returned
+// by Factory.Proof. The root is the state trie root hash converted by hash.BytesToHash256.
+func VerifyProof(root hash.Hash256, ns string, key, value []byte, proof [][]byte) bool {
+	if len(proof) == 0 {
+		return false
+	}
+	rootHash := mptrie.DefaultHashFunc(proof[len(proof)-1])
+	if hash.BytesToHash256(rootHash) != root {
+		return false
+	}
+	return mptrie.VerifyTwoLayerProof(rootHash, namespaceKey(ns), toLegacyKey(key), value, proof)
+}
+
+// Nonces returns the committed and pending nonces of the account
+func (sf *factory) Nonces(addr string) (uint64, uint64, error) {
+	return accountNonces(sf, addr)
+}
+
+// AccountState returns the account of the address
+func (sf *factory) AccountState(addr string) (*state.Account, error) {
+	return accountState(addr, func(s interface{}, opts ...protocol.StateOption) error {
+		_, err := sf.State(s, opts...)
+		return err
+	})
+}
+
+// AccountStateAtHeight returns the account of the address at the height -- archive mode
+func (sf *factory) AccountStateAtHeight(height uint64, addr string) (*state.Account, error) {
+	return accountState(addr, func(s interface{}, opts ...protocol.StateOption) error {
+		return sf.StateAtHeight(height, s, opts...)
+	})
+}
+
+// CandidateByName returns the staking candidate of name
+func (sf *factory) CandidateByName(name string) (*state.Candidate, error) {
+	return staking.StateCandidateByName(sf, name)
+}
+
+// CandidateByAddress returns the staking candidate owned by the address
+func (sf *factory) CandidateByAddress(addr string) (*state.Candidate, error) {
+	return candidateByAddress(sf, addr)
+}
+
+// PutState adds the state to the pending states, which are written by the working set of the next block
+func (sf *factory) PutState(ns string, key []byte, s state.Serializer) error {
+	p, err := pendingState(ns, key, s)
+	if err != nil {
+		return err
+	}
+	sf.mutex.Lock()
+	sf.pendingStates = append(sf.pendingStates, p)
+	sf.mutex.Unlock()
+	return nil
+}
+
+// GetState reads the state at the current height
+func (sf *factory) GetState(ns string, key []byte, s state.Deserializer) error {
+	_, err := sf.State(s, protocol.NamespaceOption(ns), protocol.KeyOption(key))
+	return err
+}
+
+// IterateStates calls fn on the accounts with the key prefix at the current height
+func (sf *factory) IterateStates(prefix []byte, fn func(key []byte, s *state.Account) error) error {
+	sf.mutex.RLock()
+	keys, values, err := readAccounts(sf.dao, prefix)
+	sf.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	return iterateAccounts(keys, values, fn)
+}
+
+// GetCodeByHash returns the contract code of the code hash
+func (sf *factory) GetCodeByHash(codeHash hash.Hash256) ([]byte, error) {
+	return contractCode(sf, codeHash)
+}
+
 // ReadView reads the view
 func (sf *factory) ReadView(name string) (interface{}, error) {
 	return sf.protocolView.Read(name)
@@ -593,12 +1029,64 @@ func legacyKeyLen() int {
 	return 20
 }
 
+// StateRoots returns the archived state roots at the heights
+func (sf *factory) StateRoots(heights []uint64) (map[uint64]hash.Hash256, error) {
+	if !sf.saveHistory {
+		return nil, ErrNoArchiveData
+	}
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	for _, height := range heights {
+		if height > sf.currentChainHeight {
+			return nil, errors.Wrapf(state.ErrHeightNotRetained, "query height %d is higher than tip height %d", height, sf.currentChainHeight)
+		}
+	}
+	kv, err := trie.NewKVStore(ArchiveTrieNamespace, sf.dao)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create db for trie")
+	}
+	roots := make(map[uint64]hash.Hash256, len(heights))
+	for _, height := range heights {
+		if _, ok := roots[height]; ok {
+			continue
+		}
+		root, err := kv.Get([]byte(fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height)))
+		if err != nil {
+			if errors.Cause(err) == trie.ErrNotExist {
+				return nil, errors.Wrapf(state.ErrHeightNotRetained, "no archive trie for %d", height)
+			}
+			return nil, errors.Wrapf(err, "failed to get state root at %d", height)
+		}
+		roots[height] = hash.BytesToHash256(root)
+	}
+	return roots, nil
+}
+
+// WorkingSetAtHeight returns a working set to run the block at height on top of the archived states at height-1
+func (sf *factory) WorkingSetAtHeight(height uint64) (protocol.WorkingSet, error) {
+	if !sf.saveHistory {
+		return nil, ErrNoArchiveData
+	}
+	if height == 0 {
+		return nil, errors.New("cannot run the genesis block")
+	}
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+	if height > sf.currentChainHeight+1 {
+		return nil, errors.Errorf("query height %d is higher than tip height %d", height-1, sf.currentChainHeight)
+	}
+	return sf.newWorkingSetAtHeight(genesis.WithGenesisContext(context.Background(), sf.cfg.Genesis), height)
+}
+
 func (sf *factory) stateAtHeight(height uint64, ns string, key []byte, s interface{}) error {
 	if !sf.saveHistory {
 		return ErrNoArchiveData
 	}
 	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, sf.dao, fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height), false)
 	if err != nil {
+		if errors.Cause(err) == trie.ErrNotExist {
+			return errors.Wrapf(state.ErrHeightNotRetained, "no archive trie for %d", height)
+		}
 		return errors.Wrapf(err, "failed to generate trie for %d", height)
 	}
 	if err := tlt.Start(context.Background()); err != nil {
@@ -6,13 +6,16 @@
 package factory
 
 import (
+	"bytes"
 	"context"
 	"encoding/csv"
 	"encoding/hex"
+	"math"
 	"math/big"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
 	"testing"
 	"time"
 
@@ -25,6 +28,7 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-election/test/mock/mock_committee"
 	"github.com/iotexproject/iotex-election/types"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
@@ -34,12 +38,14 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/unit"
 	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
@@ -298,6 +304,7 @@ func testCandidates(sf Factory, t *testing.T) {
 	)
 	require.NoError(t, err)
 	require.NoError(t, sf.Register(p))
+	require.Equal(t, []string{"rolldpos", "poll"}, sf.RegisteredProtocols())
 	gasLimit := testutil.TestGasLimit
 
 	// TODO: investigate why registry cannot be added in the Blockchain Ctx
@@ -344,6 +351,11 @@ func testCandidates(sf Factory, t *testing.T) {
 	for i, c := range candidates {
 		require.True(t, c.Equal(sc[i]))
 	}
+	page, total, err := candidatesutil.CandidatesByHeightPaged(sf, 1, 1, 1)
+	require.NoError(t, err)
+	require.EqualValues(t, len(sc), total)
+	require.Len(t, page, 1)
+	require.True(t, page[0].Equal(sc[1]))
 }
 
 func TestState(t *testing.T) {
@@ -443,6 +455,183 @@ func TestSDBState(t *testing.T) {
 	testState(sdb, t)
 }
 
+func TestSDBExportImportState(t *testing.T) {
+	require := require.New(t)
+	a := identityset.Address(28)
+	ge := genesis.Default.Clone()
+	ge.InitBalanceMap[a.String()] = "100"
+	ctx := protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(protocol.WithBlockchainCtx(protocol.WithBlockCtx(
+		context.Background(),
+		protocol.BlockCtx{
+			BlockHeight: 0,
+			Producer:    identityset.Address(27),
+			GasLimit:    1000000,
+		},
+	), protocol.BlockchainCtx{
+		ChainID: 1,
+	}), ge))
+	newStateDB := func() Factory {
+		testDBPath, err := testutil.PathOfTempFile(_stateDBPath)
+		require.NoError(err)
+		t.Cleanup(func() { testutil.CleanupPath(testDBPath) })
+		cfg := DefaultConfig
+		cfg.Genesis = ge
+		kv, err := db.CreateKVStore(db.DefaultConfig, testDBPath)
+		require.NoError(err)
+		sdb, err := NewStateDB(cfg, kv, SkipBlockValidationStateDBOption())
+		require.NoError(err)
+		require.NoError(sdb.Register(account.NewProtocol(rewarding.DepositGas)))
+		require.NoError(sdb.Register(rewarding.NewProtocol(ge.Rewarding)))
+		stk, err := staking.NewProtocol(rewarding.DepositGas, &staking.BuilderConfig{
+			Staking:                  ge.Staking,
+			PersistStakingPatchBlock: math.MaxUint64,
+		}, nil, nil, ge.OkhotskBlockHeight, ge.GreenlandBlockHeight, ge.HawaiiBlockHeight)
+		require.NoError(err)
+		require.NoError(sdb.Register(stk))
+		require.NoError(sdb.Start(ctx))
+		t.Cleanup(func() { require.NoError(sdb.Stop(ctx)) })
+		return sdb
+	}
+
+	src := newStateDB()
+	tsf, err := action.NewTransfer(1, big.NewInt(10), identityset.Address(31).String(), nil, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	elp := (&action.EnvelopeBuilder{}).SetAction(tsf).SetGasLimit(20000).SetNonce(1).Build()
+	selp, err := action.Sign(elp, identityset.PrivateKey(28))
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(selp).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	require.NoError(src.PutBlock(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: 1,
+		Producer:    identityset.Address(27),
+		GasLimit:    1000000,
+	}), &blk))
+
+	var buf bytes.Buffer
+	require.Equal(ErrNotSupported, errors.Cause(src.ExportState(ctx, 0, &buf)))
+	require.NoError(src.ExportState(ctx, 1, &buf))
+	require.Error(src.ImportState(ctx, bytes.NewReader(buf.Bytes())))
+
+	dst := newStateDB()
+	// the protocols are restarted with the genesis and feature context of the state db
+	require.NoError(dst.ImportState(context.Background(), bytes.NewReader(buf.Bytes())))
+	height, err := dst.Height()
+	require.NoError(err)
+	require.EqualValues(1, height)
+	acct, err := accountutil.AccountState(ctx, dst, a)
+	require.NoError(err)
+	require.Equal(big.NewInt(90), acct.Balance)
+	require.EqualValues(2, acct.PendingNonce())
+
+	// truncated stream
+	require.Error(newStateDB().ImportState(ctx, bytes.NewReader(buf.Bytes()[:buf.Len()-1])))
+
+	sf, err := NewFactory(DefaultConfig, db.NewMemKVStore())
+	require.NoError(err)
+	require.Equal(ErrNotSupported, errors.Cause(sf.ImportState(ctx, bytes.NewReader(buf.Bytes()))))
+}
+
+func TestFactoryExportState(t *testing.T) {
+	require := require.New(t)
+	a := identityset.Address(28)
+	b := identityset.Address(31)
+	ge := genesis.GetDefault()
+	ge.InitBalanceMap[a.String()] = "100"
+	ctx := genesis.WithGenesisContext(protocol.WithBlockchainCtx(protocol.WithBlockCtx(
+		context.Background(),
+		protocol.BlockCtx{
+			BlockHeight: 0,
+			Producer:    identityset.Address(27),
+			GasLimit:    1000000,
+		},
+	), protocol.BlockchainCtx{
+		ChainID: 1,
+	}), ge)
+	testTriePath, err := testutil.PathOfTempFile(_triePath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testTriePath)
+	cfg := DefaultConfig
+	cfg.Genesis = ge
+	cfg.Chain.EnableArchiveMode = true
+	kv, err := db.CreateKVStore(db.DefaultConfig, testTriePath)
+	require.NoError(err)
+	sf, err := NewFactory(cfg, kv, SkipBlockValidationOption())
+	require.NoError(err)
+	require.NoError(sf.Register(account.NewProtocol(rewarding.DepositGas)))
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+	tsf, err := action.NewTransfer(1, big.NewInt(10), b.String(), nil, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	elp := (&action.EnvelopeBuilder{}).SetAction(tsf).SetGasLimit(20000).SetNonce(1).Build()
+	selp, err := action.Sign(elp, identityset.PrivateKey(28))
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(selp).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	_, _, err = sf.CommitBlock(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: 1,
+		Producer:    identityset.Address(27),
+		GasLimit:    1000000,
+	}), &blk)
+	require.NoError(err)
+
+	var buf bytes.Buffer
+	require.ErrorIs(sf.ExportState(ctx, 2, &buf), state.ErrHeightNotRetained)
+	// the states at height 0 are exported from the archived state trie
+	require.NoError(sf.ExportState(ctx, 0, &buf))
+	sdbPath, err := testutil.PathOfTempFile(_stateDBPath)
+	require.NoError(err)
+	defer testutil.CleanupPath(sdbPath)
+	sdbCfg := DefaultConfig
+	sdbCfg.Genesis = ge
+	sdbKV, err := db.CreateKVStore(db.DefaultConfig, sdbPath)
+	require.NoError(err)
+	sdb, err := NewStateDB(sdbCfg, sdbKV, SkipBlockValidationStateDBOption())
+	require.NoError(err)
+	require.NoError(sdb.Register(account.NewProtocol(rewarding.DepositGas)))
+	require.NoError(sdb.Start(ctx))
+	defer func() {
+		require.NoError(sdb.Stop(ctx))
+	}()
+	require.NoError(sdb.ImportState(ctx, bytes.NewReader(buf.Bytes())))
+	height, err := sdb.Height()
+	require.NoError(err)
+	require.Zero(height)
+	acct, err := accountutil.AccountState(ctx, sdb, a)
+	require.NoError(err)
+	require.Equal(big.NewInt(100), acct.Balance)
+	_, err = sdb.State(&state.Account{}, protocol.LegacyKeyOption(hash.BytesToHash160(b.Bytes())))
+	require.ErrorIs(err, state.ErrStateNotExist)
+
+	// historical states require the archive mode
+	cfg.Chain.EnableArchiveMode = false
+	sf2, err := NewFactory(cfg, db.NewMemKVStore(), SkipBlockValidationOption())
+	require.NoError(err)
+	require.NoError(sf2.Register(account.NewProtocol(rewarding.DepositGas)))
+	require.NoError(sf2.Start(ctx))
+	defer func() {
+		require.NoError(sf2.Stop(ctx))
+	}()
+	_, _, err = sf2.CommitBlock(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: 1,
+		Producer:    identityset.Address(27),
+		GasLimit:    1000000,
+	}), &blk)
+	require.NoError(err)
+	require.ErrorIs(sf2.ExportState(ctx, 0, &buf), ErrNoArchiveData)
+}
+
 func testState(sf Factory, t *testing.T) {
 	// Create a dummy iotex address
 	a := identityset.Address(28)
@@ -503,6 +692,84 @@ func testState(sf Factory, t *testing.T) {
 	require.Equal(t, big.NewInt(90), accountA.Balance)
 }
 
+func TestWorkingSetAtHeightWithStaking(t *testing.T) {
+	require := require.New(t)
+	testTriePath, err := testutil.PathOfTempFile(_triePath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testTriePath)
+
+	owner := identityset.Address(28)
+	ge := genesis.GetDefault()
+	ge.InitBalanceMap[owner.String()] = unit.ConvertIotxToRau(2000000).String()
+	cfg := DefaultConfig
+	cfg.Genesis = ge
+	cfg.Chain.EnableArchiveMode = true
+	db1, err := db.CreateKVStore(db.DefaultConfig, testTriePath)
+	require.NoError(err)
+	sf, err := NewFactory(cfg, db1, SkipBlockValidationOption())
+	require.NoError(err)
+	require.NoError(sf.Register(account.NewProtocol(rewarding.DepositGas)))
+	stk, err := staking.NewProtocol(rewarding.DepositGas, &staking.BuilderConfig{
+		Staking:                  ge.Staking,
+		PersistStakingPatchBlock: math.MaxUint64,
+	}, nil, nil, ge.OkhotskBlockHeight, ge.GreenlandBlockHeight, ge.HawaiiBlockHeight)
+	require.NoError(err)
+	require.NoError(sf.Register(stk))
+
+	ctx := protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(protocol.WithBlockCtx(
+		context.Background(),
+		protocol.BlockCtx{
+			BlockHeight: 0,
+			Producer:    identityset.Address(27),
+			GasLimit:    testutil.TestGasLimit,
+		},
+	), ge))
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+
+	// register a candidate in block 1
+	cr, err := action.NewCandidateRegister(1, "test1", owner.String(), owner.String(), owner.String(),
+		ge.Staking.RegistrationConsts.MinSelfStake, 1, false, nil, 1000000, big.NewInt(0))
+	require.NoError(err)
+	elp := (&action.EnvelopeBuilder{}).SetAction(cr).SetGasLimit(1000000).SetNonce(1).Build()
+	selp, err := action.Sign(elp, identityset.PrivateKey(28))
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(selp).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	blkCtx := protocol.WithFeatureCtx(protocol.WithBlockchainCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight:    1,
+		BlockTimeStamp: blk.Timestamp(),
+		Producer:       identityset.Address(27),
+		GasLimit:       testutil.TestGasLimit,
+	}), protocol.BlockchainCtx{
+		ChainID: 1,
+	}))
+	_, receipts, err := sf.CommitBlock(protocol.WithRegistry(blkCtx, sf.(*factory).registry), &blk)
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Success), receipts[0].Status)
+	_, err = sf.CandidateByName("test1")
+	require.NoError(err)
+
+	// the views of the working set are built on the states at height 0, which has no candidate
+	ws, err := sf.WorkingSetAtHeight(1)
+	require.NoError(err)
+	_, err = staking.StateCandidateByName(ws, "test1")
+	require.ErrorIs(err, state.ErrStateNotExist)
+	require.NoError(ws.Process(protocol.WithRegistry(blkCtx, sf.(*factory).registry), []action.SealedEnvelope{selp}))
+	replayed, err := ws.Receipts()
+	require.NoError(err)
+	require.Len(replayed, 1)
+	require.Equal(receipts[0].Status, replayed[0].Status)
+	require.Equal(receipts[0].GasConsumed, replayed[0].GasConsumed)
+}
+
 func testHistoryState(sf Factory, t *testing.T, statetx, archive bool) {
 	// Create a dummy iotex address
 	a := identityset.Address(28)
@@ -557,7 +824,8 @@ func testHistoryState(sf Factory, t *testing.T, statetx, archive bool) {
 		AddActions([]action.SealedEnvelope{selp}...).
 		SignAndBuild(identityset.PrivateKey(27))
 	require.NoError(t, err)
-	require.NoError(t, sf.PutBlock(ctx, &blk))
+	root1, _, err := sf.CommitBlock(ctx, &blk)
+	require.NoError(t, err)
 
 	// check latest balance
 	accountA, err = accountutil.AccountState(ctx, sf, a)
@@ -574,12 +842,15 @@ func testHistoryState(sf Factory, t *testing.T, statetx, archive bool) {
 		require.Equal(t, ErrNotSupported, errors.Cause(err))
 		_, err = accountutil.AccountState(ctx, NewHistoryStateReader(sf, 0), b)
 		require.Equal(t, ErrNotSupported, errors.Cause(err))
+		_, err = sf.AccountStateAtHeight(0, a.String())
+		require.Equal(t, ErrNotSupported, errors.Cause(err))
 	} else {
 		if !archive {
 			_, err = accountutil.AccountState(ctx, NewHistoryStateReader(sf, 0), a)
 			require.Equal(t, ErrNoArchiveData, errors.Cause(err))
 			_, err = accountutil.AccountState(ctx, NewHistoryStateReader(sf, 0), b)
 			require.Equal(t, ErrNoArchiveData, errors.Cause(err))
+			require.ErrorIs(t, err, state.ErrHeightNotRetained)
 		} else {
 			accountA, err = accountutil.AccountState(ctx, NewHistoryStateReader(sf, 0), a)
 			require.NoError(t, err)
@@ -587,8 +858,81 @@ func testHistoryState(sf Factory, t *testing.T, statetx, archive bool) {
 			require.NoError(t, err)
 			require.Equal(t, big.NewInt(100), accountA.Balance)
 			require.Equal(t, big.NewInt(0), accountB.Balance)
+			accountA, err = sf.AccountStateAtHeight(0, a.String())
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(100), accountA.Balance)
+			accountB, err = sf.AccountStateAtHeight(1, b.String())
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(10), accountB.Balance)
+			_, err = sf.AccountStateAtHeight(0, b.String())
+			require.ErrorIs(t, err, state.ErrStateNotExist)
+			// the states at a height beyond the archive are not retained
+			sf.(*factory).currentChainHeight++
+			_, err = accountutil.AccountState(ctx, NewHistoryStateReader(sf, 2), a)
+			sf.(*factory).currentChainHeight--
+			require.ErrorIs(t, err, state.ErrHeightNotRetained)
 		}
 	}
+
+	// replay block 1 on top of the states at height 0
+	ws, err := sf.WorkingSetAtHeight(1)
+	if statetx || !archive {
+		require.ErrorIs(t, err, state.ErrHeightNotRetained)
+	} else {
+		require.NoError(t, err)
+		accountA, err = accountutil.AccountState(ctx, ws, a)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100), accountA.Balance)
+		replayCtx := protocol.WithFeatureCtx(protocol.WithRegistry(ctx, sf.(*factory).registry))
+		require.NoError(t, ws.Process(replayCtx, []action.SealedEnvelope{selp}))
+		receipts, err := ws.Receipts()
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		require.Equal(t, uint64(iotextypes.ReceiptStatus_Success), receipts[0].Status)
+		accountA, err = accountutil.AccountState(ctx, ws, a)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(90), accountA.Balance)
+		// the replay is not committed
+		accountA, err = accountutil.AccountState(ctx, NewHistoryStateReader(sf, 0), a)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100), accountA.Balance)
+		height, err := sf.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), height)
+
+		ws, err = sf.WorkingSetAtHeight(2)
+		require.NoError(t, err)
+		accountB, err = accountutil.AccountState(ctx, ws, b)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(10), accountB.Balance)
+		_, err = sf.WorkingSetAtHeight(0)
+		require.Error(t, err)
+		_, err = sf.WorkingSetAtHeight(3)
+		require.ErrorContains(t, err, "query height 2 is higher than tip height 1")
+	}
+
+	// state roots
+	roots, err := sf.StateRoots([]uint64{1, 0, 1})
+	if statetx || !archive {
+		require.ErrorIs(t, err, state.ErrHeightNotRetained)
+	} else {
+		require.NoError(t, err)
+		require.Len(t, roots, 2)
+		require.Equal(t, root1, roots[1])
+		require.NotEqual(t, roots[0], roots[1])
+		_, err = sf.StateRoots([]uint64{0, 2})
+		require.ErrorIs(t, err, state.ErrHeightNotRetained)
+		require.ErrorContains(t, err, "query height 2 is higher than tip height 1")
+		sf.(*factory).currentChainHeight++
+		_, err = sf.StateRoots([]uint64{2})
+		sf.(*factory).currentChainHeight--
+		require.ErrorIs(t, err, state.ErrHeightNotRetained)
+	}
+	// a missing state is told apart by errors.Is
+	sk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = sf.State(&state.Account{}, protocol.LegacyKeyOption(hash.BytesToHash160(sk.PublicKey().Address().Bytes())))
+	require.ErrorIs(t, err, state.ErrStateNotExist)
 }
 
 func testFactoryStates(sf Factory, t *testing.T) {
@@ -931,6 +1275,256 @@ func testLoadStoreHeight(sf Factory, t *testing.T) {
 	}
 }
 
+func TestSDBCheckpoint(t *testing.T) {
+	require := require.New(t)
+	a := identityset.Address(28)
+	ge := genesis.GetDefault()
+	ge.InitBalanceMap[a.String()] = "100"
+	ctx := genesis.WithGenesisContext(protocol.WithBlockchainCtx(protocol.WithBlockCtx(
+		context.Background(),
+		protocol.BlockCtx{
+			BlockHeight: 0,
+			Producer:    identityset.Address(27),
+			GasLimit:    1000000,
+		},
+	), protocol.BlockchainCtx{
+		ChainID: 1,
+	}), ge)
+	testDBPath, err := testutil.PathOfTempFile(_stateDBPath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testDBPath)
+	cfg := DefaultConfig
+	cfg.Genesis = ge
+	kv, err := db.CreateKVStore(db.DefaultConfig, testDBPath)
+	require.NoError(err)
+	sdb, err := NewStateDB(cfg, kv, SkipBlockValidationStateDBOption())
+	require.NoError(err)
+	require.NoError(sdb.Register(account.NewProtocol(rewarding.DepositGas)))
+	require.NoError(sdb.Start(ctx))
+	defer func() {
+		require.NoError(sdb.Stop(ctx))
+	}()
+
+	require.Error(sdb.Checkpoint(""))
+	require.NoError(sdb.Checkpoint("genesis"))
+	tsf, err := action.NewTransfer(1, big.NewInt(10), identityset.Address(31).String(), nil, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	elp := (&action.EnvelopeBuilder{}).SetAction(tsf).SetGasLimit(20000).SetNonce(1).Build()
+	selp, err := action.Sign(elp, identityset.PrivateKey(28))
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(selp).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	require.NoError(sdb.PutBlock(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: 1,
+		Producer:    identityset.Address(27),
+		GasLimit:    1000000,
+	}), &blk))
+	require.NoError(sdb.Checkpoint("block1"))
+
+	for _, v := range []struct {
+		name    string
+		height  uint64
+		balance int64
+		nonce   uint64
+	}{
+		{"genesis", 0, 100, 1},
+		{"block1", 1, 90, 2},
+		{"genesis", 0, 100, 1},
+	} {
+		require.NoError(sdb.RestoreCheckpoint(v.name))
+		height, err := sdb.Height()
+		require.NoError(err)
+		require.Equal(v.height, height)
+		acct, err := sdb.AccountState(a.String())
+		require.NoError(err)
+		require.Equal(big.NewInt(v.balance), acct.Balance)
+		require.Equal(v.nonce, acct.PendingNonce())
+	}
+	// the recipient created after the checkpoint is removed
+	_, err = sdb.AccountState(identityset.Address(31).String())
+	require.ErrorIs(err, state.ErrStateNotExist)
+	require.ErrorIs(sdb.RestoreCheckpoint("unknown"), state.ErrStateNotExist)
+
+	testCheckpointDeletion(sdb, ctx, func(ctx context.Context, height uint64) (*workingSet, error) {
+		return sdb.(*stateDB).newWorkingSet(ctx, height)
+	}, t)
+
+	// the in-memory kvstore doesn't list its buckets
+	sdb, err = NewStateDB(cfg, db.NewMemKVStore())
+	require.NoError(err)
+	require.ErrorIs(sdb.Checkpoint("genesis"), ErrNotSupported)
+}
+
+// testCheckpointDeletion deletes an account and writes a state of a namespace out of SnapshotNamespaces after a
+// checkpoint, and then restores the checkpoint, both directly and by resuming an interrupted restore on start
+func testCheckpointDeletion(
+	sf Factory,
+	ctx context.Context,
+	newWorkingSet func(context.Context, uint64) (*workingSet, error),
+	t *testing.T,
+) {
+	require := require.New(t)
+	key := hash.BytesToHash160(identityset.Address(28).Bytes())
+	commit := func(update func(*workingSet) error) {
+		height, err := sf.Height()
+		require.NoError(err)
+		ws, err := newWorkingSet(ctx, height+1)
+		require.NoError(err)
+		require.NoError(update(ws))
+		require.NoError(ws.finalize())
+		require.NoError(ws.Commit(ctx))
+		// restart to load the committed height
+		require.NoError(sf.Stop(ctx))
+		require.NoError(sf.Start(ctx))
+	}
+	commit(func(ws *workingSet) error {
+		_, err := ws.PutState(&protocol.SerializableBytes{1}, protocol.NamespaceOption("testNamespace"), protocol.KeyOption(key[:]))
+		return err
+	})
+	height, err := sf.Height()
+	require.NoError(err)
+	acct, err := sf.AccountState(identityset.Address(28).String())
+	require.NoError(err)
+	balance := acct.Balance
+	require.NoError(sf.Checkpoint("deletion"))
+	commit(func(ws *workingSet) error {
+		if _, err := ws.DelState(protocol.NamespaceOption(AccountKVNamespace), protocol.KeyOption(key[:])); err != nil {
+			return err
+		}
+		_, err := ws.PutState(&protocol.SerializableBytes{2}, protocol.NamespaceOption("testNamespace"), protocol.KeyOption(key[:]))
+		return err
+	})
+	_, err = sf.AccountState(identityset.Address(28).String())
+	require.ErrorIs(err, state.ErrStateNotExist)
+
+	check := func() {
+		h, err := sf.Height()
+		require.NoError(err)
+		require.Equal(height, h)
+		acct, err := sf.AccountState(identityset.Address(28).String())
+		require.NoError(err)
+		require.Equal(balance, acct.Balance)
+		var v protocol.SerializableBytes
+		require.NoError(sf.GetState("testNamespace", key[:], &v))
+		require.Equal(protocol.SerializableBytes{1}, v)
+	}
+	require.NoError(sf.RestoreCheckpoint("deletion"))
+	check()
+
+	// a restore interrupted by a crash is resumed on start
+	commit(func(ws *workingSet) error {
+		_, err := ws.DelState(protocol.NamespaceOption(AccountKVNamespace), protocol.KeyOption(key[:]))
+		return err
+	})
+	require.NoError(sf.Stop(ctx))
+	var kv db.KVStore
+	switch f := sf.(type) {
+	case *factory:
+		kv = f.dao
+	case *stateDB:
+		kv = f.dao
+	}
+	require.NoError(kv.Start(ctx))
+	require.NoError(kv.Put(_checkpointRestoreNamespace, []byte(_checkpointRestoreKey), []byte("deletion")))
+	require.NoError(kv.Stop(ctx))
+	require.NoError(sf.Start(ctx))
+	check()
+	_, err = kv.Get(_checkpointRestoreNamespace, []byte(_checkpointRestoreKey))
+	require.ErrorIs(err, db.ErrNotExist)
+}
+
+func TestFactoryCheckpoint(t *testing.T) {
+	require := require.New(t)
+	a := identityset.Address(28)
+	b := identityset.Address(31)
+	ge := genesis.GetDefault()
+	ge.InitBalanceMap[a.String()] = "100"
+	ctx := genesis.WithGenesisContext(protocol.WithBlockchainCtx(protocol.WithBlockCtx(
+		context.Background(),
+		protocol.BlockCtx{
+			BlockHeight: 0,
+			Producer:    identityset.Address(27),
+			GasLimit:    1000000,
+		},
+	), protocol.BlockchainCtx{
+		ChainID: 1,
+	}), ge)
+	testTriePath, err := testutil.PathOfTempFile(_triePath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testTriePath)
+	cfg := DefaultConfig
+	cfg.Genesis = ge
+	kv, err := db.CreateKVStore(db.DefaultConfig, testTriePath)
+	require.NoError(err)
+	sf, err := NewFactory(cfg, kv, SkipBlockValidationOption())
+	require.NoError(err)
+	require.NoError(sf.Register(account.NewProtocol(rewarding.DepositGas)))
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+
+	require.Error(sf.Checkpoint(""))
+	require.NoError(sf.Checkpoint("genesis"))
+	rh, err := kv.Get(ArchiveTrieNamespace, []byte(ArchiveTrieRootKey))
+	require.NoError(err)
+	root0 := hash.BytesToHash256(rh)
+	tsf, err := action.NewTransfer(1, big.NewInt(10), b.String(), nil, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	elp := (&action.EnvelopeBuilder{}).SetAction(tsf).SetGasLimit(20000).SetNonce(1).Build()
+	selp, err := action.Sign(elp, identityset.PrivateKey(28))
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(selp).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	blkCtx := protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight: 1,
+		Producer:    identityset.Address(27),
+		GasLimit:    1000000,
+	})
+	root1, _, err := sf.CommitBlock(blkCtx, &blk)
+	require.NoError(err)
+
+	require.NotEqual(root0, root1)
+
+	require.NoError(sf.RestoreCheckpoint("genesis"))
+	height, err := sf.Height()
+	require.NoError(err)
+	require.Zero(height)
+	root, err := sf.(*factory).rootHash()
+	require.NoError(err)
+	require.Equal(root0, hash.BytesToHash256(root))
+	acct, err := sf.AccountState(a.String())
+	require.NoError(err)
+	require.Equal(big.NewInt(100), acct.Balance)
+	// the recipient created after the checkpoint is removed
+	_, err = sf.AccountState(b.String())
+	require.ErrorIs(err, state.ErrStateNotExist)
+
+	// the block is committed again on top of the restored states
+	root2, _, err := sf.CommitBlock(blkCtx, &blk)
+	require.NoError(err)
+	require.Equal(root1, root2)
+	acct, err = sf.AccountState(b.String())
+	require.NoError(err)
+	require.Equal(big.NewInt(10), acct.Balance)
+
+	require.ErrorIs(sf.RestoreCheckpoint("unknown"), state.ErrStateNotExist)
+
+	testCheckpointDeletion(sf, ctx, func(ctx context.Context, height uint64) (*workingSet, error) {
+		return sf.(*factory).newWorkingSet(ctx, height)
+	}, t)
+}
+
 func TestRunActions(t *testing.T) {
 	require := require.New(t)
 	testTriePath, err := testutil.PathOfTempFile(_triePath)
@@ -956,7 +1550,46 @@ func TestRunActions(t *testing.T) {
 		require.NoError(sf.Stop(ctx))
 		testutil.CleanupPath(testTriePath)
 	}()
-	testCommit(sf, t)
+	prevRoot, err := sf.(*factory).rootHash()
+	require.NoError(err)
+	committedRoot := testCommit(sf, t)
+	require.NotEqual(hash.BytesToHash256(prevRoot), committedRoot)
+	currRoot, err := sf.(*factory).rootHash()
+	require.NoError(err)
+	require.Equal(hash.BytesToHash256(currRoot), committedRoot)
+	root := testFactoryRunActions(sf, t)
+	require.NotEqual(committedRoot, root)
+
+	// prove the account states against the state root
+	rootHash, err := sf.(*factory).rootHash()
+	require.NoError(err)
+	require.Equal(hash.BytesToHash256(rootHash), root)
+	for _, addr := range []address.Address{identityset.Address(28), identityset.Address(29)} {
+		key := hash.BytesToHash160(addr.Bytes())
+		value, err := sf.(*factory).dao.Get(AccountKVNamespace, key[:])
+		require.NoError(err)
+		proof, err := sf.Proof(AccountKVNamespace, key[:])
+		require.NoError(err)
+		require.True(VerifyProof(root, AccountKVNamespace, key[:], value, proof))
+		require.False(VerifyProof(root, AccountKVNamespace, key[:], value[1:], proof))
+		require.False(VerifyProof(root, evm.ContractKVNameSpace, key[:], value, proof))
+		require.False(VerifyProof(hash.ZeroHash256, AccountKVNamespace, key[:], value, proof))
+	}
+	key := hash.BytesToHash160(identityset.Address(30).Bytes())
+	_, err = sf.Proof(AccountKVNamespace, key[:])
+	require.Equal(state.ErrStateNotExist, errors.Cause(err))
+
+	testGetCodeByHash(sf, sf.(*factory).dao, t)
+	testPutGetState(sf, t)
+	testCandidateLookup(sf, t)
+	testIterateStates(sf, len(cfg.Genesis.InitBalanceMap), t)
+	// the state root covers the states put
+	rootHash, err = sf.(*factory).rootHash()
+	require.NoError(err)
+	require.NotEqual(hash.BytesToHash256(rootHash), root)
+	proof, err := sf.Proof("testNamespace", []byte("key"))
+	require.NoError(err)
+	require.True(VerifyProof(hash.BytesToHash256(rootHash), "testNamespace", []byte("key"), []byte("value"), proof))
 }
 
 func TestSTXRunActions(t *testing.T) {
@@ -987,10 +1620,183 @@ func TestSTXRunActions(t *testing.T) {
 		require.NoError(sdb.Stop(ctx))
 		testutil.CleanupPath(testStateDBPath)
 	}()
-	testCommit(sdb, t)
+	require.Equal(hash.ZeroHash256, testCommit(sdb, t))
+	require.Equal(hash.ZeroHash256, testFactoryRunActions(sdb, t))
+	_, err = sdb.Proof(AccountKVNamespace, identityset.Address(28).Bytes())
+	require.Equal(ErrNotSupported, errors.Cause(err))
+
+	testGetCodeByHash(sdb, sdb.(*stateDB).dao, t)
+	testPutGetState(sdb, t)
+	testCandidateLookup(sdb, t)
+	testIterateStates(sdb, len(cfg.Genesis.InitBalanceMap), t)
+}
+
+func testCandidateLookup(factory Factory, t *testing.T) {
+	require := require.New(t)
+	// the candidates are looked up in the view of the staking protocol, which is not registered
+	_, err := factory.CandidateByName("test1")
+	require.Equal(protocol.ErrNoName, errors.Cause(err))
+	_, err = factory.CandidateByAddress(identityset.Address(1).String())
+	require.Equal(protocol.ErrNoName, errors.Cause(err))
+	_, err = factory.CandidateByAddress("io1invalid")
+	require.ErrorContains(err, "invalid address io1invalid")
+}
+
+func testIterateStates(factory Factory, numAccounts int, t *testing.T) {
+	require := require.New(t)
+	var (
+		keys     [][]byte
+		balances = map[string]*big.Int{}
+	)
+	require.NoError(factory.IterateStates(nil, func(key []byte, s *state.Account) error {
+		keys = append(keys, key)
+		balances[hex.EncodeToString(key)] = s.Balance
+		return nil
+	}))
+	require.Len(keys, numAccounts)
+	require.True(sort.SliceIsSorted(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 }))
+	for _, addr := range []address.Address{identityset.Address(28), identityset.Address(29)} {
+		acct, err := factory.AccountState(addr.String())
+		require.NoError(err)
+		require.Equal(acct.Balance, balances[hex.EncodeToString(addr.Bytes())])
+	}
+
+	// prefix
+	addr := identityset.Address(28).Bytes()
+	var count int
+	require.NoError(factory.IterateStates(addr[:4], func(key []byte, s *state.Account) error {
+		require.Equal(addr, key)
+		count++
+		return nil
+	}))
+	require.Equal(1, count)
+	require.NoError(factory.IterateStates([]byte("no such prefix"), func([]byte, *state.Account) error {
+		return errors.New("should not be called")
+	}))
+
+	// stop on the first error
+	count = 0
+	errStop := errors.New("stop")
+	require.Equal(errStop, factory.IterateStates(nil, func([]byte, *state.Account) error {
+		count++
+		return errStop
+	}))
+	require.Equal(1, count)
+}
+
+func TestIterateStatesWithProtocolStates(t *testing.T) {
+	require := require.New(t)
+	testDBPath, err := testutil.PathOfTempFile(_stateDBPath)
+	require.NoError(err)
+	defer testutil.CleanupPath(testDBPath)
+
+	cfg := DefaultConfig
+	cfg.Genesis = genesis.GetDefault()
+	cfg.Genesis.Delegates = []genesis.Delegate{
+		{
+			OperatorAddrStr: identityset.Address(0).String(),
+			RewardAddrStr:   identityset.Address(1).String(),
+			VotesStr:        "10",
+		},
+	}
+	registry := protocol.NewRegistry()
+	require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+	require.NoError(rewarding.NewProtocol(cfg.Genesis.Rewarding).Register(registry))
+	require.NoError(rolldpos.NewProtocol(36, 36, 20).Register(registry))
+	require.NoError(poll.NewLifeLongDelegatesProtocol(cfg.Genesis.Delegates).Register(registry))
+	kv, err := db.CreateKVStore(db.DefaultConfig, testDBPath)
+	require.NoError(err)
+	sdb, err := NewStateDB(cfg, kv, SkipBlockValidationStateDBOption(), RegistryStateDBOption(registry))
+	require.NoError(err)
+	ctx := protocol.WithFeatureWithHeightCtx(protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), cfg.Genesis),
+		protocol.BlockCtx{},
+	))
+	require.NoError(sdb.Start(ctx))
+	defer func() {
+		require.NoError(sdb.Stop(ctx))
+	}()
+
+	// the rewarding and poll protocols store their states in the account namespace keyed by hash160 as well
+	keys, _, err := kv.Filter(AccountKVNamespace, func(k, v []byte) bool { return len(k) == legacyKeyLen() }, nil, nil)
+	require.NoError(err)
+	require.Greater(len(keys), len(cfg.Genesis.InitBalanceMap))
+
+	var n int
+	require.NoError(sdb.IterateStates(nil, func(key []byte, s *state.Account) error {
+		n++
+		return nil
+	}))
+	require.Equal(len(cfg.Genesis.InitBalanceMap), n)
+}
+
+func testPutGetState(factory Factory, t *testing.T) {
+	require := require.New(t)
+	height, err := factory.Height()
+	require.NoError(err)
+	var value protocol.SerializableBytes
+	require.ErrorIs(factory.GetState("testNamespace", []byte("key"), &value), state.ErrStateNotExist)
+
+	require.NoError(factory.PutState("testNamespace", []byte("key"), protocol.SerializableBytes("value")))
+	// the state is pending until the next block is committed
+	require.ErrorIs(factory.GetState("testNamespace", []byte("key"), &value), state.ErrStateNotExist)
+	h, err := factory.Height()
+	require.NoError(err)
+	require.Equal(height, h)
+
+	ctx := genesis.WithGenesisContext(
+		protocol.WithBlockchainCtx(
+			protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+				BlockHeight: height + 1,
+				Producer:    identityset.Address(27),
+				GasLimit:    1000000,
+			}),
+			protocol.BlockchainCtx{Tip: protocol.TipInfo{Height: height}},
+		),
+		genesis.Default,
+	)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(height + 1).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	require.NoError(factory.PutBlock(ctx, &blk))
+	require.NoError(factory.GetState("testNamespace", []byte("key"), &value))
+	require.Equal(protocol.SerializableBytes("value"), value)
+	h, err = factory.Height()
+	require.NoError(err)
+	require.Equal(height+1, h)
+
+	// the states of the protocols in the tree and the ones internal to the factory are not writable
+	for _, v := range []struct {
+		ns  string
+		key string
+	}{
+		{"", "key"},
+		{ArchiveTrieNamespace, ArchiveTrieRootKey},
+		{ArchiveNamespacePrefix + "-" + AccountKVNamespace, "key"},
+		{AccountKVNamespace, CurrentHeightKey},
+		{AccountKVNamespace, "key"},
+		{evm.ContractKVNameSpace, "key"},
+	} {
+		require.Error(factory.PutState(v.ns, []byte(v.key), protocol.SerializableBytes("value")))
+	}
+}
+
+func testGetCodeByHash(factory Factory, dao db.KVStore, t *testing.T) {
+	require := require.New(t)
+	code := []byte{0x60, 0x80, 0x60, 0x40}
+	codeHash := hash.Hash256b(code)
+	_, err := factory.GetCodeByHash(codeHash)
+	require.ErrorIs(err, state.ErrStateNotExist)
+
+	require.NoError(dao.Put(evm.CodeKVNameSpace, codeHash[:], code))
+	stored, err := factory.GetCodeByHash(codeHash)
+	require.NoError(err)
+	require.Equal(code, stored)
 }
 
-func testCommit(factory Factory, t *testing.T) {
+func testCommit(factory Factory, t *testing.T) hash.Hash256 {
 	require := require.New(t)
 	a := identityset.Address(28).String()
 	priKeyA := identityset.PrivateKey(28)
@@ -1041,7 +1847,113 @@ func testCommit(factory Factory, t *testing.T) {
 		SignAndBuild(identityset.PrivateKey(27))
 	require.NoError(err)
 
-	require.NoError(factory.PutBlock(ctx, &blk))
+	root, receipts, err := factory.CommitBlock(ctx, &blk)
+	require.NoError(err)
+	require.Len(receipts, 2)
+	require.Equal(blk.Receipts, receipts)
+	return root
+}
+
+func testFactoryRunActions(factory Factory, t *testing.T) hash.Hash256 {
+	require := require.New(t)
+	a := identityset.Address(28)
+	b := identityset.Address(29)
+
+	newTransfer := func(recipient address.Address, amount int64, priKey crypto.PrivateKey) action.SealedEnvelope {
+		tx, err := action.NewTransfer(2, big.NewInt(amount), recipient.String(), nil, uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		selp, err := action.Sign((&action.EnvelopeBuilder{}).SetNonce(2).SetAction(tx).Build(), priKey)
+		require.NoError(err)
+		return selp
+	}
+	ctx := genesis.WithGenesisContext(
+		protocol.WithBlockchainCtx(
+			protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+				BlockHeight: 2,
+				Producer:    identityset.Address(27),
+				GasLimit:    1000000,
+			}),
+			protocol.BlockchainCtx{},
+		),
+		genesis.Default,
+	)
+	balance := func(addr address.Address) *big.Int {
+		acct, err := accountutil.AccountState(ctx, factory, addr)
+		require.NoError(err)
+		return acct.Balance
+	}
+	balanceA, balanceB := balance(a), balance(b)
+
+	// wrong height
+	_, err := factory.RunActions(ctx, 3, []action.SealedEnvelope{newTransfer(b, 5, identityset.PrivateKey(28))})
+	require.Error(err)
+
+	// the transfer from b fails, and the one from a is rolled back
+	_, err = factory.RunActions(ctx, 2, []action.SealedEnvelope{
+		newTransfer(b, 5, identityset.PrivateKey(28)),
+		newTransfer(a, 1000, identityset.PrivateKey(29)),
+	})
+	require.Error(err)
+	height, err := factory.Height()
+	require.NoError(err)
+	require.EqualValues(1, height)
+	require.Equal(balanceA, balance(a))
+	require.Equal(balanceB, balance(b))
+
+	acts := []action.SealedEnvelope{
+		newTransfer(b, 5, identityset.PrivateKey(28)),
+		newTransfer(a, 1, identityset.PrivateKey(29)),
+	}
+	root, receipts, err := factory.RunActionsWithRoot(ctx, 2, acts)
+	require.NoError(err)
+	require.Len(receipts, 2)
+	for _, r := range receipts {
+		require.Equal(uint64(iotextypes.ReceiptStatus_Success), r.Status)
+	}
+	height, err = factory.Height()
+	require.NoError(err)
+	require.EqualValues(2, height)
+	require.Equal(new(big.Int).Sub(balanceA, big.NewInt(4)), balance(a))
+	require.Equal(new(big.Int).Add(balanceB, big.NewInt(4)), balance(b))
+	// the block at the height can't be put any more
+	blk, err := block.NewTestingBuilder().
+		SetHeight(2).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(acts...).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	require.Error(factory.PutBlock(ctx, &blk))
+
+	// a has committed the transfers of nonce 1 and 2
+	committed, pending, err := factory.Nonces(a.String())
+	require.NoError(err)
+	require.EqualValues(2, committed)
+	require.EqualValues(3, pending)
+	// a legacy account created in genesis without activity
+	committed, pending, err = factory.Nonces(identityset.Address(10).String())
+	require.NoError(err)
+	require.Zero(committed)
+	require.EqualValues(1, pending)
+	// an account not recorded on the chain
+	sk, err := crypto.GenerateKey()
+	require.NoError(err)
+	committed, pending, err = factory.Nonces(sk.PublicKey().Address().String())
+	require.NoError(err)
+	require.Zero(committed)
+	require.Zero(pending)
+	_, _, err = factory.Nonces("io1invalid")
+	require.Error(err)
+
+	acct, err := factory.AccountState(a.String())
+	require.NoError(err)
+	require.Equal(balance(a), acct.Balance)
+	require.EqualValues(3, acct.PendingNonce())
+	_, err = factory.AccountState(sk.PublicKey().Address().String())
+	require.ErrorIs(err, state.ErrStateNotExist)
+	_, err = factory.AccountState("io1invalid")
+	require.Error(err)
+	return root
 }
 
 func TestPickAndRunActions(t *testing.T) {
@@ -0,0 +1,413 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory/snapshotpb"
+)
+
+const (
+	// CheckpointNamespace is the bucket recording the checkpoints taken by Checkpoint, the states of which are stored
+	// in the buckets prefixed by it
+	CheckpointNamespace = "Checkpoint"
+
+	// _checkpointRestoreNamespace is the bucket marking the checkpoint being restored
+	_checkpointRestoreNamespace = "CheckpointRestore"
+	_checkpointRestoreKey       = "name"
+
+	// _importBatchSize is the number of state entries written to db in one batch by ImportState
+	_importBatchSize = 10000
+)
+
+// SnapshotNamespaces are the namespaces of the states exported by ExportState
+var SnapshotNamespaces = []string{
+	AccountKVNamespace,
+	protocol.SystemNamespace,
+	staking.StakingNameSpace,
+	staking.CandidateNameSpace,
+	staking.CandsMapNS,
+	rewarding.V2RewardingNamespace,
+	evm.CodeKVNameSpace,
+	evm.ContractKVNameSpace,
+	evm.PreimageKVNameSpace,
+}
+
+// exportState writes the states in SnapshotNamespaces read by readStates to w as a stream of varint length-prefixed
+// StateEntry messages
+func exportState(w io.Writer, readStates func(string) ([][]byte, [][]byte, error)) error {
+	bw := bufio.NewWriter(w)
+	for _, ns := range SnapshotNamespaces {
+		keys, values, err := readStates(ns)
+		if err != nil {
+			return err
+		}
+		for i := range keys {
+			if err := writeStateEntry(bw, &snapshotpb.StateEntry{
+				Namespace: ns,
+				Key:       keys[i],
+				Value:     values[i],
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// kvStates returns the states of namespace ns in kv
+func kvStates(kv db.KVStore, ns string) ([][]byte, [][]byte, error) {
+	keys, values, err := kv.Filter(ns, func(k, v []byte) bool { return true }, nil, nil)
+	switch errors.Cause(err) {
+	case nil:
+		return keys, values, nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, nil, nil
+	default:
+		return nil, nil, errors.Wrapf(err, "failed to read states of namespace %s", ns)
+	}
+}
+
+// trieStates returns the states of namespace ns in the state trie, and the keys of the states in kv which are not in
+// the trie. The trie keeps the hash of the key only, so the keys are taken from kv, and a state in the trie whose key
+// has been deleted from kv since cannot be read.
+func trieStates(kv db.KVStore, tlt trie.TwoLayerTrie, ns string) ([][]byte, [][]byte, [][]byte, error) {
+	kvKeys, _, err := kvStates(kv, ns)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var (
+		keys, values, missing [][]byte
+		found                 = make(map[string]struct{}, len(kvKeys))
+	)
+	for _, k := range kvKeys {
+		if ns == AccountKVNamespace && string(k) == CurrentHeightKey {
+			continue
+		}
+		value, err := readState(tlt, ns, k)
+		switch errors.Cause(err) {
+		case nil:
+			keys = append(keys, k)
+			values = append(values, value)
+			found[string(toLegacyKey(k))] = struct{}{}
+		case state.ErrStateNotExist:
+			missing = append(missing, k)
+		default:
+			return nil, nil, nil, err
+		}
+	}
+	iter, err := mptrie.NewLayerTwoLeafIterator(tlt, namespaceKey(ns), legacyKeyLen())
+	if errors.Cause(err) == trie.ErrNotExist {
+		return keys, values, missing, nil
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for {
+		k, _, err := iter.Next()
+		if err == trie.ErrEndOfIterator {
+			break
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if _, ok := found[string(k)]; !ok {
+			return nil, nil, nil, errors.Errorf("cannot read state %x of namespace %s, which has been deleted since", k, ns)
+		}
+	}
+	return keys, values, missing, nil
+}
+
+// archivedTrie returns the state trie with the root archived at height, which must be stopped after use
+func archivedTrie(kv db.KVStore, height uint64) (trie.TwoLayerTrie, error) {
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, kv, fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height), false)
+	if err != nil {
+		if errors.Cause(err) == trie.ErrNotExist {
+			return nil, errors.Wrapf(state.ErrHeightNotRetained, "no archive trie for %d", height)
+		}
+		return nil, errors.Wrap(err, "failed to generate state trie")
+	}
+	if err := tlt.Start(context.Background()); err != nil {
+		return nil, err
+	}
+	return tlt, nil
+}
+
+// importState writes the states read from r to kv, and returns the number of states imported
+func importState(kv db.KVStore, r io.Reader) (int, error) {
+	var (
+		br = bufio.NewReader(r)
+		b  = batch.NewBatch()
+		n  int
+	)
+	for {
+		entry, err := readStateEntry(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return n, err
+		}
+		b.Put(entry.GetNamespace(), entry.GetKey(), entry.GetValue(), "failed to import state")
+		n++
+		if b.Size() >= _importBatchSize {
+			if err := kv.WriteBatch(b); err != nil {
+				return n, err
+			}
+			b.Clear()
+		}
+	}
+	if err := kv.WriteBatch(b); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// checkpointState copies the states of all buckets in kv into the buckets of the checkpoint name key by key, and
+// records the checkpoint along with the height once the copy completes. An existing checkpoint of the same name is
+// overwritten.
+func checkpointState(kv db.KVStore, name string, height uint64) error {
+	if name == "" {
+		return errors.New("checkpoint name is empty")
+	}
+	store, ok := kv.(db.KVStoreWithBuckets)
+	if !ok {
+		return errors.Wrap(ErrNotSupported, "checkpoint requires a kvstore listing its buckets")
+	}
+	// the record is deleted first, so that a checkpoint partially overwritten is never restored
+	if err := kv.Delete(CheckpointNamespace, []byte(name)); err != nil {
+		return errors.Wrapf(err, "failed to delete checkpoint %s", name)
+	}
+	buckets, err := store.Buckets()
+	if err != nil {
+		return errors.Wrap(err, "failed to list buckets")
+	}
+	prefix := checkpointBucketPrefix(name)
+	for _, ns := range buckets {
+		if !strings.HasPrefix(ns, prefix) {
+			continue
+		}
+		if err := pageStates(store, ns, func(keys, _ [][]byte) error {
+			b := batch.NewBatch()
+			for _, k := range keys {
+				b.Delete(ns, k, "failed to delete checkpoint state")
+			}
+			return kv.WriteBatch(b)
+		}); err != nil {
+			return errors.Wrapf(err, "failed to delete checkpoint %s", name)
+		}
+	}
+	for _, ns := range buckets {
+		if isCheckpointBucket(ns) {
+			continue
+		}
+		if err := pageStates(store, ns, func(keys, values [][]byte) error {
+			b := batch.NewBatch()
+			for i := range keys {
+				b.Put(prefix+ns, keys[i], values[i], "failed to store checkpoint state")
+			}
+			return kv.WriteBatch(b)
+		}); err != nil {
+			return errors.Wrapf(err, "failed to store checkpoint %s", name)
+		}
+	}
+	return errors.Wrapf(
+		kv.Put(CheckpointNamespace, []byte(name), byteutil.Uint64ToBytesBigEndian(height)),
+		"failed to store checkpoint %s", name,
+	)
+}
+
+// restoreCheckpoint replaces the states of all buckets in kv with the ones stored under the checkpoint name. The
+// restore is marked in _checkpointRestoreNamespace until it completes, so that a restore interrupted by a crash is
+// resumed by resumeCheckpointRestore rather than leaving partially restored states.
+func restoreCheckpoint(kv db.KVStore, name string) error {
+	if _, err := readCheckpoint(kv, name); err != nil {
+		return err
+	}
+	store, ok := kv.(db.KVStoreWithBuckets)
+	if !ok {
+		return errors.Wrap(ErrNotSupported, "checkpoint requires a kvstore listing its buckets")
+	}
+	if err := kv.Put(_checkpointRestoreNamespace, []byte(_checkpointRestoreKey), []byte(name)); err != nil {
+		return errors.Wrapf(err, "failed to mark the restore of checkpoint %s", name)
+	}
+	if err := restoreStates(store, name); err != nil {
+		return errors.Wrapf(err, "failed to restore checkpoint %s", name)
+	}
+	return kv.Delete(_checkpointRestoreNamespace, []byte(_checkpointRestoreKey))
+}
+
+// resumeCheckpointRestore completes the restore of checkpoint interrupted by a crash, if any
+func resumeCheckpointRestore(kv db.KVStore) error {
+	name, err := kv.Get(_checkpointRestoreNamespace, []byte(_checkpointRestoreKey))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil
+	default:
+		return errors.Wrap(err, "failed to read the restore of checkpoint")
+	}
+	store, ok := kv.(db.KVStoreWithBuckets)
+	if !ok {
+		return errors.Wrap(ErrNotSupported, "checkpoint requires a kvstore listing its buckets")
+	}
+	if err := restoreStates(store, string(name)); err != nil {
+		return errors.Wrapf(err, "failed to resume restoring checkpoint %s", name)
+	}
+	return kv.Delete(_checkpointRestoreNamespace, []byte(_checkpointRestoreKey))
+}
+
+// restoreStates deletes the states which are not in the checkpoint name, including the whole buckets created after
+// the checkpoint, and then writes the states in the checkpoint back. Both steps are idempotent.
+func restoreStates(kv db.KVStoreWithBuckets, name string) error {
+	buckets, err := kv.Buckets()
+	if err != nil {
+		return errors.Wrap(err, "failed to list buckets")
+	}
+	prefix := checkpointBucketPrefix(name)
+	for _, ns := range buckets {
+		if isCheckpointBucket(ns) {
+			continue
+		}
+		if err := pageStates(kv, ns, func(keys, _ [][]byte) error {
+			b := batch.NewBatch()
+			for _, k := range keys {
+				_, err := kv.Get(prefix+ns, k)
+				switch errors.Cause(err) {
+				case nil:
+				case db.ErrNotExist, db.ErrBucketNotExist:
+					b.Delete(ns, k, "failed to delete state")
+				default:
+					return err
+				}
+			}
+			return kv.WriteBatch(b)
+		}); err != nil {
+			return err
+		}
+	}
+	for _, cp := range buckets {
+		if !strings.HasPrefix(cp, prefix) {
+			continue
+		}
+		ns := strings.TrimPrefix(cp, prefix)
+		if err := pageStates(kv, cp, func(keys, values [][]byte) error {
+			b := batch.NewBatch()
+			for i := range keys {
+				b.Put(ns, keys[i], values[i], "failed to restore state")
+			}
+			return kv.WriteBatch(b)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pageStates calls fn with the states of namespace ns page by page in key order, so that the states are never held
+// in memory or written in one batch as a whole. fn may delete the states of the page.
+func pageStates(kv db.KVStoreWithBuckets, ns string, fn func([][]byte, [][]byte) error) error {
+	var start []byte
+	for {
+		keys, values, err := kv.Page(ns, start, _importBatchSize)
+		switch errors.Cause(err) {
+		case nil:
+		case db.ErrNotExist, db.ErrBucketNotExist:
+			return nil
+		default:
+			return errors.Wrapf(err, "failed to read states of namespace %s", ns)
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		if err := fn(keys, values); err != nil {
+			return err
+		}
+		if len(keys) < _importBatchSize {
+			return nil
+		}
+		// the smallest key after the last one of the page
+		last := keys[len(keys)-1]
+		start = append(append(make([]byte, 0, len(last)+1), last...), 0)
+	}
+}
+
+// checkpointBucketPrefix returns the prefix of the buckets storing the states of the checkpoint name, which has the
+// hash of the name of fixed length, so that the prefix of a checkpoint is never the prefix of another one
+func checkpointBucketPrefix(name string) string {
+	h := hash.Hash160b([]byte(name))
+	return fmt.Sprintf("%s-%x-", CheckpointNamespace, h[:])
+}
+
+// isCheckpointBucket returns true if the bucket stores the checkpoints rather than the states
+func isCheckpointBucket(ns string) bool {
+	return ns == CheckpointNamespace || ns == _checkpointRestoreNamespace || strings.HasPrefix(ns, CheckpointNamespace+"-")
+}
+
+func readCheckpoint(kv db.KVStore, name string) ([]byte, error) {
+	value, err := kv.Get(CheckpointNamespace, []byte(name))
+	switch errors.Cause(err) {
+	case nil:
+		return value, nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, errors.Wrapf(state.ErrStateNotExist, "checkpoint %s doesn't exist", name)
+	default:
+		return nil, errors.Wrapf(err, "failed to read checkpoint %s", name)
+	}
+}
+
+func writeStateEntry(w io.Writer, entry *snapshotpb.StateEntry) error {
+	data, err := proto.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize state entry")
+	}
+	var prefix [binary.MaxVarintLen64]byte
+	if _, err := w.Write(prefix[:binary.PutUvarint(prefix[:], uint64(len(data)))]); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// readStateEntry returns io.EOF if there is no more entry
+func readStateEntry(r *bufio.Reader) (*snapshotpb.StateEntry, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, errors.Wrap(err, "failed to read the length of state entry")
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errors.Wrap(err, "failed to read state entry")
+	}
+	entry := &snapshotpb.StateEntry{}
+	if err := proto.Unmarshal(data, entry); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize state entry")
+	}
+	return entry, nil
+}
@@ -8,6 +8,7 @@ package factory
 import (
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 	"sync"
 	"time"
@@ -47,6 +48,7 @@ type stateDB struct {
 	protocolView             protocol.View
 	skipBlockValidationOnPut bool
 	ps                       *patchStore
+	pendingStates            []*patch // states put by PutState, pending for the next block
 }
 
 // StateDBOption sets stateDB construction parameter
@@ -118,6 +120,9 @@ func (sdb *stateDB) Start(ctx context.Context) error {
 	if err := sdb.dao.Start(ctx); err != nil {
 		return err
 	}
+	if err := resumeCheckpointRestore(sdb.dao); err != nil {
+		return err
+	}
 	// check factory height
 	h, err := sdb.dao.Get(AccountKVNamespace, []byte(CurrentHeightKey))
 	switch errors.Cause(err) {
@@ -195,14 +200,23 @@ func (sdb *stateDB) newWorkingSet(ctx context.Context, height uint64) (*workingS
 	if err := store.Start(ctx); err != nil {
 		return nil, err
 	}
-
-	return newWorkingSet(height, store), nil
+	ws := newWorkingSet(height, store)
+	if height == sdb.currentChainHeight+1 {
+		if err := ws.applyPendingStates(sdb.pendingStates); err != nil {
+			return nil, err
+		}
+	}
+	return ws, nil
 }
 
 func (sdb *stateDB) Register(p protocol.Protocol) error {
 	return p.Register(sdb.registry)
 }
 
+func (sdb *stateDB) RegisteredProtocols() []string {
+	return sdb.registry.IDs()
+}
+
 func (sdb *stateDB) Validate(ctx context.Context, blk *block.Block) error {
 	ctx = protocol.WithRegistry(ctx, sdb.registry)
 	key := generateWorkingSetCacheKey(blk.Header, blk.Header.ProducerAddress())
@@ -224,6 +238,27 @@ func (sdb *stateDB) Validate(ctx context.Context, blk *block.Block) error {
 	return nil
 }
 
+// RunBlock runs the block in a working set without committing it
+func (sdb *stateDB) RunBlock(ctx context.Context, blk *block.Block) (hash.Hash256, []*action.Receipt, error) {
+	ctx = protocol.WithRegistry(ctx, sdb.registry)
+	sdb.mutex.RLock()
+	height := sdb.currentChainHeight + 1
+	if blk.Height() != height {
+		sdb.mutex.RUnlock()
+		return hash.ZeroHash256, nil, errors.Errorf("invalid block height %d, expecting %d", blk.Height(), height)
+	}
+	ws, err := sdb.newWorkingSet(ctx, height)
+	sdb.mutex.RUnlock()
+	if err != nil {
+		return hash.ZeroHash256, nil, errors.Wrap(err, "failed to obtain working set from state db")
+	}
+	digest, err := ws.runBlock(ctx, blk)
+	if err != nil {
+		return hash.ZeroHash256, nil, errors.Wrap(err, "failed to run block with workingset in statedb")
+	}
+	return digest, ws.receipts, nil
+}
+
 // NewBlockBuilder returns block builder which hasn't been signed yet
 func (sdb *stateDB) NewBlockBuilder(
 	ctx context.Context,
@@ -232,9 +267,8 @@ func (sdb *stateDB) NewBlockBuilder(
 ) (*block.Builder, error) {
 	ctx = protocol.WithRegistry(ctx, sdb.registry)
 	sdb.mutex.RLock()
-	currHeight := sdb.currentChainHeight
+	ws, err := sdb.newWorkingSet(ctx, sdb.currentChainHeight+1)
 	sdb.mutex.RUnlock()
-	ws, err := sdb.newWorkingSet(ctx, currHeight+1)
 	if err != nil {
 		return nil, err
 	}
@@ -272,9 +306,8 @@ func (sdb *stateDB) SimulateExecution(
 	defer span.End()
 
 	sdb.mutex.RLock()
-	currHeight := sdb.currentChainHeight
+	ws, err := sdb.newWorkingSet(ctx, sdb.currentChainHeight+1)
 	sdb.mutex.RUnlock()
-	ws, err := sdb.newWorkingSet(ctx, currHeight+1)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -285,24 +318,30 @@ func (sdb *stateDB) SimulateExecution(
 // ReadContractStorage reads contract's storage
 func (sdb *stateDB) ReadContractStorage(ctx context.Context, contract address.Address, key []byte) ([]byte, error) {
 	sdb.mutex.RLock()
-	currHeight := sdb.currentChainHeight
+	ws, err := sdb.newWorkingSet(ctx, sdb.currentChainHeight+1)
 	sdb.mutex.RUnlock()
-	ws, err := sdb.newWorkingSet(ctx, currHeight+1)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate working set from state db")
 	}
 	return evm.ReadContractStorage(ctx, ws, contract, key)
 }
 
-// PutBlock persists all changes in RunActions() into the DB
+// PutBlock runs all the actions of the block through the registered protocols and commits the state changes into
+// the DB
 func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
+	_, _, err := sdb.CommitBlock(ctx, blk)
+	return err
+}
+
+// CommitBlock commits the block as PutBlock does, and returns the state root after the commit along with the receipts
+func (sdb *stateDB) CommitBlock(ctx context.Context, blk *block.Block) (hash.Hash256, []*action.Receipt, error) {
 	sdb.mutex.Lock()
 	timer := sdb.timerFactory.NewTimer("Commit")
 	sdb.mutex.Unlock()
 	defer timer.End()
 	producer := blk.PublicKey().Address()
 	if producer == nil {
-		return errors.New("failed to get address")
+		return hash.ZeroHash256, nil, errors.New("failed to get address")
 	}
 	g := genesis.MustExtractGenesisContext(ctx)
 	ctx = protocol.WithBlockCtx(
@@ -318,7 +357,7 @@ func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 	key := generateWorkingSetCacheKey(blk.Header, blk.Header.ProducerAddress())
 	ws, isExist, err := sdb.getFromWorkingSets(ctx, key)
 	if err != nil {
-		return err
+		return hash.ZeroHash256, nil, err
 	}
 	if !isExist {
 		if !sdb.skipBlockValidationOnPut {
@@ -328,16 +367,25 @@ func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 		}
 		if err != nil {
 			log.L().Error("Failed to update state.", zap.Error(err))
-			return err
+			return hash.ZeroHash256, nil, err
 		}
 	}
 	sdb.mutex.Lock()
 	defer sdb.mutex.Unlock()
 	receipts, err := ws.Receipts()
 	if err != nil {
-		return err
+		return hash.ZeroHash256, nil, err
 	}
 	blk.Receipts = receipts
+	if err := sdb.commitWorkingSet(ctx, ws); err != nil {
+		return hash.ZeroHash256, nil, err
+	}
+	// the stateDB keeps no state trie
+	return hash.ZeroHash256, receipts, nil
+}
+
+// commitWorkingSet commits the working set at the next height. The caller must hold the lock.
+func (sdb *stateDB) commitWorkingSet(ctx context.Context, ws *workingSet) error {
 	h, _ := ws.Height()
 	if sdb.currentChainHeight+1 != h {
 		// another working set with correct version already committed, do nothing
@@ -350,10 +398,57 @@ func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 	if err := ws.Commit(ctx); err != nil {
 		return err
 	}
+	sdb.pendingStates = sdb.pendingStates[ws.pendingStates:]
 	sdb.currentChainHeight = h
 	return nil
 }
 
+// RunActions runs the actions at height in a working set, and commits it if all the actions succeed. The block
+// context at height is expected in ctx.
+func (sdb *stateDB) RunActions(ctx context.Context, height uint64, acts []action.SealedEnvelope) ([]*action.Receipt, error) {
+	_, receipts, err := sdb.RunActionsWithRoot(ctx, height, acts)
+	return receipts, err
+}
+
+// RunActionsWithRoot runs the actions as RunActions does. The root is always hash.ZeroHash256, since the stateDB keeps
+// no state trie.
+func (sdb *stateDB) RunActionsWithRoot(ctx context.Context, height uint64, acts []action.SealedEnvelope) (hash.Hash256, []*action.Receipt, error) {
+	ws, err := sdb.runActions(ctx, height, acts)
+	if err != nil {
+		return hash.ZeroHash256, nil, err
+	}
+	receipts, err := ws.Receipts()
+	if err != nil {
+		return hash.ZeroHash256, nil, err
+	}
+	sdb.mutex.Lock()
+	defer sdb.mutex.Unlock()
+	if err := sdb.commitWorkingSet(ctx, ws); err != nil {
+		return hash.ZeroHash256, nil, err
+	}
+	return hash.ZeroHash256, receipts, nil
+}
+
+// runActions runs the actions in a working set at height, which must be the next height. The working set is
+// discarded on any failure, so none of the actions is committed.
+func (sdb *stateDB) runActions(ctx context.Context, height uint64, acts []action.SealedEnvelope) (*workingSet, error) {
+	ctx = protocol.WithFeatureCtx(protocol.WithRegistry(ctx, sdb.registry))
+	sdb.mutex.RLock()
+	if sdb.currentChainHeight+1 != height {
+		sdb.mutex.RUnlock()
+		return nil, errors.Errorf("current state height %d + 1 doesn't match height %d", sdb.currentChainHeight, height)
+	}
+	ws, err := sdb.newWorkingSet(ctx, height)
+	sdb.mutex.RUnlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain working set from state db")
+	}
+	if err := ws.Process(ctx, acts); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
 func (sdb *stateDB) DeleteTipBlock(_ context.Context, _ *block.Block) error {
 	return errors.Wrap(ErrNotSupported, "cannot delete tip block from state db")
 }
@@ -401,6 +496,170 @@ func (sdb *stateDB) StatesAtHeight(height uint64, opts ...protocol.StateOption)
 	return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
 }
 
+// WorkingSetAtHeight is not supported, as state db does not keep the historical states
+func (sdb *stateDB) WorkingSetAtHeight(height uint64) (protocol.WorkingSet, error) {
+	return nil, errors.Wrap(ErrNoArchiveData, "state db does not support archive mode")
+}
+
+// StateRoots is not supported, as state db does not keep the historical states
+func (sdb *stateDB) StateRoots(heights []uint64) (map[uint64]hash.Hash256, error) {
+	return nil, errors.Wrap(ErrNoArchiveData, "state db does not support archive mode")
+}
+
+// ExportState writes the states at height to w, only the current height is supported
+func (sdb *stateDB) ExportState(ctx context.Context, height uint64, w io.Writer) error {
+	sdb.mutex.RLock()
+	defer sdb.mutex.RUnlock()
+	if height != sdb.currentChainHeight {
+		return errors.Wrapf(ErrNotSupported, "cannot export states at height %d other than tip height %d", height, sdb.currentChainHeight)
+	}
+	return exportState(w, func(ns string) ([][]byte, [][]byte, error) {
+		return kvStates(sdb.dao, ns)
+	})
+}
+
+// ImportState imports the states read from r, and restarts the protocols with the imported states. The state db must
+// be started and have no block committed yet.
+func (sdb *stateDB) ImportState(ctx context.Context, r io.Reader) error {
+	sdb.mutex.Lock()
+	if sdb.currentChainHeight != 0 {
+		sdb.mutex.Unlock()
+		return errors.Errorf("cannot import states into state db at height %d", sdb.currentChainHeight)
+	}
+	n, err := importState(sdb.dao, r)
+	if err != nil {
+		sdb.mutex.Unlock()
+		return errors.Wrapf(err, "failed to import states after %d states", n)
+	}
+	h, err := sdb.dao.Get(AccountKVNamespace, []byte(CurrentHeightKey))
+	if err != nil {
+		sdb.mutex.Unlock()
+		return errors.Wrap(err, "failed to get imported height")
+	}
+	sdb.currentChainHeight = byteutil.BytesToUint64(h)
+	sdb.workingsets.Clear()
+	sdb.mutex.Unlock()
+
+	// protocols read states via the state db, so they are restarted without holding the lock
+	ctx = protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(
+		protocol.WithRegistry(ctx, sdb.registry),
+		sdb.cfg.Genesis,
+	))
+	view, err := sdb.registry.StartAll(ctx, sdb)
+	if err != nil {
+		return err
+	}
+	sdb.mutex.Lock()
+	sdb.protocolView = view
+	sdb.mutex.Unlock()
+	return nil
+}
+
+// Checkpoint stores the states at the current height under the name
+func (sdb *stateDB) Checkpoint(name string) error {
+	sdb.mutex.RLock()
+	defer sdb.mutex.RUnlock()
+	return checkpointState(sdb.dao, name, sdb.currentChainHeight)
+}
+
+// RestoreCheckpoint restores the states stored under the name, and restarts the protocols with the restored states
+func (sdb *stateDB) RestoreCheckpoint(name string) error {
+	sdb.mutex.Lock()
+	if err := restoreCheckpoint(sdb.dao, name); err != nil {
+		sdb.mutex.Unlock()
+		return err
+	}
+	h, err := sdb.dao.Get(AccountKVNamespace, []byte(CurrentHeightKey))
+	if err != nil {
+		sdb.mutex.Unlock()
+		return errors.Wrap(err, "failed to get restored height")
+	}
+	sdb.currentChainHeight = byteutil.BytesToUint64(h)
+	sdb.workingsets.Clear()
+	sdb.mutex.Unlock()
+
+	// protocols read states via the state db, so they are restarted without holding the lock
+	ctx := protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(
+		protocol.WithRegistry(context.Background(), sdb.registry),
+		sdb.cfg.Genesis,
+	))
+	view, err := sdb.registry.StartAll(ctx, sdb)
+	if err != nil {
+		return err
+	}
+	sdb.mutex.Lock()
+	sdb.protocolView = view
+	sdb.mutex.Unlock()
+	return nil
+}
+
+// Proof is not supported, because the state db doesn't maintain a state trie
+func (sdb *stateDB) Proof(ns string, key []byte) ([][]byte, error) {
+	return nil, errors.Wrap(ErrNotSupported, "state db has no state trie to prove states")
+}
+
+// Nonces returns the committed and pending nonces of the account
+func (sdb *stateDB) Nonces(addr string) (uint64, uint64, error) {
+	return accountNonces(sdb, addr)
+}
+
+// AccountState returns the account of the address
+func (sdb *stateDB) AccountState(addr string) (*state.Account, error) {
+	return accountState(addr, func(s interface{}, opts ...protocol.StateOption) error {
+		_, err := sdb.State(s, opts...)
+		return err
+	})
+}
+
+// AccountStateAtHeight is not supported, as state db does not keep the historical states
+func (sdb *stateDB) AccountStateAtHeight(height uint64, addr string) (*state.Account, error) {
+	return nil, errors.Wrap(ErrNotSupported, "state db does not support archive mode")
+}
+
+// CandidateByName returns the staking candidate of name
+func (sdb *stateDB) CandidateByName(name string) (*state.Candidate, error) {
+	return staking.StateCandidateByName(sdb, name)
+}
+
+// CandidateByAddress returns the staking candidate owned by the address
+func (sdb *stateDB) CandidateByAddress(addr string) (*state.Candidate, error) {
+	return candidateByAddress(sdb, addr)
+}
+
+// PutState adds the state to the pending states, which are written by the working set of the next block
+func (sdb *stateDB) PutState(ns string, key []byte, s state.Serializer) error {
+	p, err := pendingState(ns, key, s)
+	if err != nil {
+		return err
+	}
+	sdb.mutex.Lock()
+	sdb.pendingStates = append(sdb.pendingStates, p)
+	sdb.mutex.Unlock()
+	return nil
+}
+
+// GetState reads the state at the current height
+func (sdb *stateDB) GetState(ns string, key []byte, s state.Deserializer) error {
+	_, err := sdb.State(s, protocol.NamespaceOption(ns), protocol.KeyOption(key))
+	return err
+}
+
+// IterateStates calls fn on the accounts with the key prefix at the current height
+func (sdb *stateDB) IterateStates(prefix []byte, fn func(key []byte, s *state.Account) error) error {
+	sdb.mutex.RLock()
+	keys, values, err := readAccounts(sdb.dao, prefix)
+	sdb.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	return iterateAccounts(keys, values, fn)
+}
+
+// GetCodeByHash returns the contract code of the code hash
+func (sdb *stateDB) GetCodeByHash(codeHash hash.Hash256) ([]byte, error) {
+	return contractCode(sdb, codeHash)
+}
+
 // ReadView reads the view
 func (sdb *stateDB) ReadView(name string) (interface{}, error) {
 	return sdb.protocolView.Read(name)
@@ -466,8 +725,7 @@ func (sdb *stateDB) getFromWorkingSets(ctx context.Context, key hash.Hash256) (*
 		return nil, false, errors.New("type assertion failed to be WorkingSet")
 	}
 	sdb.mutex.RLock()
-	currHeight := sdb.currentChainHeight
+	tx, err := sdb.newWorkingSet(ctx, sdb.currentChainHeight+1)
 	sdb.mutex.RUnlock()
-	tx, err := sdb.newWorkingSet(ctx, currHeight+1)
 	return tx, false, err
 }
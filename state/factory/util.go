@@ -6,15 +6,23 @@
 package factory
 
 import (
+	"bytes"
 	"context"
+	"math/big"
+	"strings"
 
 	"github.com/iotexproject/go-pkgs/bloom"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/accountpb"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/db"
@@ -134,6 +142,140 @@ func readStates(kvStore db.KVStore, namespace string, keys [][]byte) ([][]byte,
 	return values, nil
 }
 
+// readAccounts reads the states keyed by hash160 with prefix in the account namespace in the key order, which are the
+// accounts and the legacy states of other protocols, the current height stored in the same namespace is skipped
+func readAccounts(kvStore db.KVStore, prefix []byte) ([][]byte, [][]byte, error) {
+	keys, values, err := kvStore.Filter(AccountKVNamespace, func(k, v []byte) bool {
+		return len(k) == legacyKeyLen() && bytes.HasPrefix(k, prefix)
+	}, prefix, nil)
+	switch errors.Cause(err) {
+	case nil:
+		return keys, values, nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, nil, nil
+	default:
+		return nil, nil, errors.Wrapf(err, "failed to read accounts with prefix %x", prefix)
+	}
+}
+
+func iterateAccounts(keys, values [][]byte, fn func(key []byte, s *state.Account) error) error {
+	for i := range keys {
+		account, ok := decodeAccount(values[i])
+		if !ok {
+			continue
+		}
+		if err := fn(keys[i], account); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeAccount decodes the value as an account. The account namespace is shared with the legacy states of the poll
+// and rewarding protocols, which are keyed by hash160 as well, so a value having fields unknown to the account, or
+// an invalid balance or type, is not an account.
+func decodeAccount(value []byte) (*state.Account, bool) {
+	acPb := &accountpb.Account{}
+	if len(value) == 0 || proto.Unmarshal(value, acPb) != nil || len(acPb.ProtoReflect().GetUnknown()) != 0 {
+		return nil, false
+	}
+	if _, ok := accountpb.AccountType_name[int32(acPb.Type)]; !ok {
+		return nil, false
+	}
+	if _, ok := new(big.Int).SetString(acPb.Balance, 10); !ok {
+		return nil, false
+	}
+	account := &state.Account{}
+	account.FromProto(acPb)
+	return account, true
+}
+
+// accountNonces returns the committed and pending nonces of the account, both are zero if the account doesn't exist
+func accountNonces(sr protocol.StateReader, addr string) (uint64, uint64, error) {
+	a, err := address.FromString(addr)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid address %s", addr)
+	}
+	account := &state.Account{}
+	_, err = sr.State(account, protocol.LegacyKeyOption(hash.BytesToHash160(a.Bytes())))
+	switch errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		return 0, 0, nil
+	default:
+		return 0, 0, errors.Wrapf(err, "failed to load account %s", addr)
+	}
+	pending := account.PendingNonce()
+	switch {
+	case !account.IsLegacy():
+		return pending, pending, nil
+	case pending == 0:
+		// the pending nonce of a legacy account is never 0 unless it overflows
+		return 0, 0, nil
+	default:
+		// the nonce of a legacy account starts from 1
+		return pending - 1, pending, nil
+	}
+}
+
+// accountState returns the account of the address read by read, the error wraps state.ErrStateNotExist if the account
+// is not recorded
+func accountState(addr string, read func(interface{}, ...protocol.StateOption) error) (*state.Account, error) {
+	a, err := address.FromString(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address %s", addr)
+	}
+	account := &state.Account{}
+	if err := read(account, protocol.LegacyKeyOption(hash.BytesToHash160(a.Bytes()))); err != nil {
+		return nil, errors.Wrapf(err, "failed to load account %s", addr)
+	}
+	return account, nil
+}
+
+func candidateByAddress(sr protocol.StateReader, addr string) (*state.Candidate, error) {
+	owner, err := address.FromString(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address %s", addr)
+	}
+	return staking.StateCandidateByOwner(sr, owner)
+}
+
+// pendingState returns the state to put along with the next block, it returns an error if the namespace belongs to
+// the protocols in the tree or is internal to the factory
+func pendingState(ns string, key []byte, s state.Serializer) (*patch, error) {
+	switch {
+	case ns == "":
+		return nil, errors.New("namespace is empty")
+	case ns == ArchiveTrieNamespace, isCheckpointBucket(ns), strings.HasPrefix(ns, ArchiveNamespacePrefix):
+		return nil, errors.Errorf("namespace %s is internal to the factory", ns)
+	}
+	for _, v := range SnapshotNamespaces {
+		if ns == v {
+			return nil, errors.Errorf("namespace %s belongs to the protocols", ns)
+		}
+	}
+	value, err := s.Serialize()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize the state of key %x in namespace %s", key, ns)
+	}
+	return &patch{
+		Type:      _Put,
+		Namespace: ns,
+		Key:       append([]byte{}, key...),
+		Value:     value,
+	}, nil
+}
+
+// contractCode returns the contract code of the code hash, the error wraps state.ErrStateNotExist if no contract has
+// deployed the code
+func contractCode(sr protocol.StateReader, codeHash hash.Hash256) ([]byte, error) {
+	var code protocol.SerializableBytes
+	if _, err := sr.State(&code, protocol.NamespaceOption(evm.CodeKVNameSpace), protocol.KeyOption(codeHash[:])); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the code of hash %x", codeHash)
+	}
+	return code, nil
+}
+
 func newTwoLayerTrie(ns string, dao db.KVStore, rootKey string, create bool) (trie.TwoLayerTrie, error) {
 	dbForTrie, err := trie.NewKVStore(ns, dao)
 	if err != nil {
@@ -0,0 +1,83 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account/accountpb"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_chainmanager"
+)
+
+func TestAccountNonces(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the account of identityset.Address(i) is accounts[i], and identityset.Address(len(accounts)) doesn't exist
+	accounts := []*accountpb.Account{
+		{Type: accountpb.AccountType_DEFAULT},
+		{Type: accountpb.AccountType_DEFAULT, Nonce: 2},
+		{Type: accountpb.AccountType_DEFAULT, Nonce: math.MaxUint64},
+		{Type: accountpb.AccountType_ZERO_NONCE},
+		{Type: accountpb.AccountType_ZERO_NONCE, Nonce: 2},
+	}
+	keys := map[hash.Hash160]*accountpb.Account{}
+	for i, acct := range accounts {
+		keys[hash.BytesToHash160(identityset.Address(i).Bytes())] = acct
+	}
+	failing := identityset.Address(len(accounts) + 1)
+	sr := mock_chainmanager.NewMockStateReader(ctrl)
+	sr.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+		cfg, err := protocol.CreateStateConfig(opts...)
+		require.NoError(err)
+		key := hash.BytesToHash160(cfg.Key)
+		if key == hash.BytesToHash160(failing.Bytes()) {
+			return 0, errors.New("db failure")
+		}
+		acct, ok := keys[key]
+		if !ok {
+			return 0, state.ErrStateNotExist
+		}
+		s.(*state.Account).FromProto(acct)
+		return 0, nil
+	}).AnyTimes()
+
+	for i, v := range []struct {
+		committed, pending uint64
+	}{
+		// legacy account without activity
+		{0, 1},
+		// legacy account having committed the nonces 1 and 2
+		{2, 3},
+		// legacy account whose pending nonce overflows
+		{0, 0},
+		// zero nonce account without activity
+		{0, 0},
+		// zero nonce account having committed the nonces 0 and 1
+		{2, 2},
+		// account not recorded
+		{0, 0},
+	} {
+		committed, pending, err := accountNonces(sr, identityset.Address(i).String())
+		require.NoError(err)
+		require.Equal(v.committed, committed, i)
+		require.Equal(v.pending, pending, i)
+	}
+	_, _, err := accountNonces(sr, failing.String())
+	require.ErrorContains(err, "db failure")
+	_, _, err = accountNonces(sr, "io1invalid")
+	require.Error(err)
+}
@@ -49,6 +49,8 @@ type (
 		finalized bool
 		dock      protocol.Dock
 		receipts  []*action.Receipt
+		// number of the pending states of PutState written by the working set
+		pendingStates int
 	}
 )
 
@@ -60,6 +62,17 @@ func newWorkingSet(height uint64, store workingSetStore) *workingSet {
 	}
 }
 
+// applyPendingStates writes the pending states of PutState, which are committed along with the working set
+func (ws *workingSet) applyPendingStates(states []*patch) error {
+	for _, p := range states {
+		if err := ws.store.Put(p.Namespace, p.Key, p.Value); err != nil {
+			return errors.Wrapf(err, "failed to put the pending state of key %x in namespace %s", p.Key, p.Namespace)
+		}
+	}
+	ws.pendingStates = len(states)
+	return nil
+}
+
 func (ws *workingSet) digest() (hash.Hash256, error) {
 	if !ws.finalized {
 		return hash.ZeroHash256, errors.New("workingset has not been finalized yet")
@@ -576,39 +589,44 @@ func updateReceiptIndex(receipts []*action.Receipt) {
 }
 
 func (ws *workingSet) ValidateBlock(ctx context.Context, blk *block.Block) error {
+	digest, err := ws.runBlock(ctx, blk)
+	if err != nil {
+		return err
+	}
+	if !blk.VerifyDeltaStateDigest(digest) {
+		return errors.Wrapf(block.ErrDeltaStateMismatch, "digest in block '%x' vs digest in workingset '%x'", blk.DeltaStateDigest(), digest)
+	}
+	receiptRoot := calculateReceiptRoot(ws.receipts)
+	if !blk.VerifyReceiptRoot(receiptRoot) {
+		return errors.Wrapf(block.ErrReceiptRootMismatch, "receipt root in block '%x' vs receipt root in workingset '%x'", blk.ReceiptRoot(), receiptRoot)
+	}
+
+	return nil
+}
+
+// runBlock validates the nonces and the system actions of the block, runs the actions, and returns the digest of the
+// state changes. The digest and the receipts are not verified against the block header.
+func (ws *workingSet) runBlock(ctx context.Context, blk *block.Block) (hash.Hash256, error) {
 	if protocol.MustGetFeatureCtx(ctx).SkipSystemActionNonce {
 		if err := ws.validateNonceSkipSystemAction(ctx, blk); err != nil {
-			return errors.Wrap(err, "failed to validate nonce")
+			return hash.ZeroHash256, errors.Wrap(err, "failed to validate nonce")
 		}
 	} else {
 		if err := ws.validateNonce(ctx, blk); err != nil {
-			return errors.Wrap(err, "failed to validate nonce")
+			return hash.ZeroHash256, errors.Wrap(err, "failed to validate nonce")
 		}
 	}
 	if protocol.MustGetFeatureCtx(ctx).ValidateSystemAction {
 		if err := ws.validateSystemActionLayout(ctx, blk.RunnableActions().Actions()); err != nil {
-			return err
+			return hash.ZeroHash256, err
 		}
 	}
 
 	if err := ws.process(ctx, blk.RunnableActions().Actions()); err != nil {
 		log.L().Error("Failed to update state.", zap.Uint64("height", ws.height), zap.Error(err))
-		return err
+		return hash.ZeroHash256, err
 	}
-
-	digest, err := ws.digest()
-	if err != nil {
-		return err
-	}
-	if !blk.VerifyDeltaStateDigest(digest) {
-		return errors.Wrapf(block.ErrDeltaStateMismatch, "digest in block '%x' vs digest in workingset '%x'", blk.DeltaStateDigest(), digest)
-	}
-	receiptRoot := calculateReceiptRoot(ws.receipts)
-	if !blk.VerifyReceiptRoot(receiptRoot) {
-		return errors.Wrapf(block.ErrReceiptRootMismatch, "receipt root in block '%x' vs receipt root in workingset '%x'", blk.ReceiptRoot(), receiptRoot)
-	}
-
-	return nil
+	return ws.digest()
 }
 
 func (ws *workingSet) CreateBuilder(
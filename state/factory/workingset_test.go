@@ -250,6 +250,61 @@ func TestWorkingSet_ValidateBlock(t *testing.T) {
 	}
 }
 
+func TestWorkingSet_RunBlock(t *testing.T) {
+	require := require.New(t)
+	registry := protocol.NewRegistry()
+	require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+	cfg := Config{
+		Chain:   blockchain.DefaultConfig,
+		Genesis: genesis.TestDefault(),
+	}
+	cfg.Genesis.InitBalanceMap[identityset.Address(28).String()] = "100000000"
+	var (
+		f1, _          = NewFactory(cfg, db.NewMemKVStore(), RegistryOption(registry))
+		f2, _          = NewStateDB(cfg, db.NewMemKVStore(), RegistryStateDBOption(registry))
+		factories      = []Factory{f1, f2}
+		digestHash, _  = hash.HexStringToHash256("43f69c954ea0138917d69a01f7ba47da74c99cb2c6229f5969a7f0bf53efb775")
+		receiptRoot, _ = hash.HexStringToHash256("b8aaff4d845664a7a3f341f677365dafcdae0ae99a7fea821c7cc42c320acefe")
+	)
+
+	ctx := protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), cfg.Genesis),
+		protocol.BlockCtx{},
+	)
+	require.NoError(f1.Start(ctx))
+	require.NoError(f2.Start(ctx))
+	defer func() {
+		require.NoError(f1.Stop(ctx))
+		require.NoError(f2.Stop(ctx))
+	}()
+
+	zctx := protocol.WithBlockCtx(context.Background(),
+		protocol.BlockCtx{
+			BlockHeight: uint64(1),
+			Producer:    identityset.Address(27),
+			GasLimit:    testutil.TestGasLimit * 100000,
+		})
+	zctx = genesis.WithGenesisContext(zctx, cfg.Genesis)
+	zctx = protocol.WithFeatureCtx(protocol.WithBlockchainCtx(zctx, protocol.BlockchainCtx{
+		ChainID: 1,
+	}))
+	for _, f := range factories {
+		// the claimed digest and receipt root are not verified
+		blk := makeBlock(t, hash.ZeroHash256, hash.Hash256b([]byte("test")), hash.Hash256b([]byte("test")), makeTransferAction(t, 1))
+		digest, receipts, err := f.RunBlock(zctx, blk)
+		require.NoError(err)
+		require.Equal(digestHash, digest)
+		require.Equal(receiptRoot, calculateReceiptRoot(receipts))
+		// nothing is committed
+		height, err := f.Height()
+		require.NoError(err)
+		require.Zero(height)
+
+		_, _, err = f.RunBlock(zctx, makeBlock(t, hash.ZeroHash256, receiptRoot, digestHash, makeTransferAction(t, 3)))
+		require.Equal(action.ErrNonceTooHigh, errors.Cause(err))
+	}
+}
+
 func TestWorkingSet_ValidateBlock_SystemAction(t *testing.T) {
 	require := require.New(t)
 	cfg := Config{
@@ -71,6 +71,21 @@ func newFactoryWorkingSetStore(view protocol.View, flusher db.KVStoreFlusher) (w
 	}, nil
 }
 
+// newFactoryWorkingSetStoreAtHeight creates a working set store on top of the archive trie at height
+func newFactoryWorkingSetStoreAtHeight(view protocol.View, flusher db.KVStoreFlusher, height uint64) (workingSetStore, error) {
+	tlt, err := newTwoLayerTrie(ArchiveTrieNamespace, flusher.KVStoreWithBuffer(), fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &factoryWorkingSetStore{
+		flusher:   flusher,
+		view:      view,
+		tlt:       tlt,
+		trieRoots: make(map[int][]byte),
+	}, nil
+}
+
 func (store *stateDBWorkingSetStore) Start(context.Context) error {
 	return nil
 }
@@ -18,6 +18,10 @@ var (
 
 	// ErrStateNotExist is the error that the state does not exist
 	ErrStateNotExist = errors.New("state does not exist")
+
+	// ErrHeightNotRetained is the error that the states at the height are not retained, e.g., the node doesn't run in
+	// archive mode
+	ErrHeightNotRetained = errors.New("states at the height are not retained")
 )
 
 // State is the interface, which defines the common methods for state struct to be handled by state factory
@@ -0,0 +1,132 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mock_factory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// LifecycleTrackingFactory wraps a MockFactory, and reports an error to the test if the states are read before the
+// factory is started or after it is stopped. The calls are still passed on to the MockFactory, so the expectations
+// of the reads are set by EXPECT as usual.
+type LifecycleTrackingFactory struct {
+	*MockFactory
+	t       gomock.TestReporter
+	mutex   sync.RWMutex
+	started bool
+	stopped bool
+}
+
+// NewLifecycleTrackingFactory creates a MockFactory tracking its lifecycle, which reports the reads out of the
+// lifecycle by t.Errorf
+func NewLifecycleTrackingFactory(ctrl *gomock.Controller, t gomock.TestReporter) *LifecycleTrackingFactory {
+	return &LifecycleTrackingFactory{
+		MockFactory: NewMockFactory(ctrl),
+		t:           t,
+	}
+}
+
+// Start starts the factory, which is only taken as started if the MockFactory returns no error
+func (f *LifecycleTrackingFactory) Start(ctx context.Context) error {
+	if err := f.MockFactory.Start(ctx); err != nil {
+		return err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.started = true
+	return nil
+}
+
+// Stop stops the factory
+func (f *LifecycleTrackingFactory) Stop(ctx context.Context) error {
+	f.mutex.Lock()
+	f.stopped = true
+	f.mutex.Unlock()
+	return f.MockFactory.Stop(ctx)
+}
+
+// State reads the state at the current height
+func (f *LifecycleTrackingFactory) State(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+	f.checkRunning("State")
+	return f.MockFactory.State(s, opts...)
+}
+
+// States reads the states at the current height
+func (f *LifecycleTrackingFactory) States(opts ...protocol.StateOption) (uint64, state.Iterator, error) {
+	f.checkRunning("States")
+	return f.MockFactory.States(opts...)
+}
+
+// StateAtHeight reads the state at the height
+func (f *LifecycleTrackingFactory) StateAtHeight(height uint64, s interface{}, opts ...protocol.StateOption) error {
+	f.checkRunning("StateAtHeight")
+	return f.MockFactory.StateAtHeight(height, s, opts...)
+}
+
+// StatesAtHeight reads the states at the height
+func (f *LifecycleTrackingFactory) StatesAtHeight(height uint64, opts ...protocol.StateOption) (state.Iterator, error) {
+	f.checkRunning("StatesAtHeight")
+	return f.MockFactory.StatesAtHeight(height, opts...)
+}
+
+// Nonces reads the committed and pending nonces of the account
+func (f *LifecycleTrackingFactory) Nonces(addr string) (uint64, uint64, error) {
+	f.checkRunning("Nonces")
+	return f.MockFactory.Nonces(addr)
+}
+
+// AccountState reads the account at the current height
+func (f *LifecycleTrackingFactory) AccountState(addr string) (*state.Account, error) {
+	f.checkRunning("AccountState")
+	return f.MockFactory.AccountState(addr)
+}
+
+// AccountStateAtHeight reads the account at the height
+func (f *LifecycleTrackingFactory) AccountStateAtHeight(height uint64, addr string) (*state.Account, error) {
+	f.checkRunning("AccountStateAtHeight")
+	return f.MockFactory.AccountStateAtHeight(height, addr)
+}
+
+// CandidateByName reads the staking candidate of the name
+func (f *LifecycleTrackingFactory) CandidateByName(name string) (*state.Candidate, error) {
+	f.checkRunning("CandidateByName")
+	return f.MockFactory.CandidateByName(name)
+}
+
+// CandidateByAddress reads the staking candidate owned by the address
+func (f *LifecycleTrackingFactory) CandidateByAddress(addr string) (*state.Candidate, error) {
+	f.checkRunning("CandidateByAddress")
+	return f.MockFactory.CandidateByAddress(addr)
+}
+
+// GetState reads the state of the key in the namespace
+func (f *LifecycleTrackingFactory) GetState(ns string, key []byte, s state.Deserializer) error {
+	f.checkRunning("GetState")
+	return f.MockFactory.GetState(ns, key, s)
+}
+
+// IterateStates calls fn on the accounts with the key prefix
+func (f *LifecycleTrackingFactory) IterateStates(prefix []byte, fn func([]byte, *state.Account) error) error {
+	f.checkRunning("IterateStates")
+	return f.MockFactory.IterateStates(prefix, fn)
+}
+
+func (f *LifecycleTrackingFactory) checkRunning(method string) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	switch {
+	case !f.started:
+		f.t.Errorf("factory %s is called before the factory is started", method)
+	case f.stopped:
+		f.t.Errorf("factory %s is called after the factory is stopped", method)
+	}
+}
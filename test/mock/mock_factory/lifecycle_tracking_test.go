@@ -0,0 +1,65 @@
+// Copyright (c) 2023 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mock_factory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type errorRecorder struct {
+	errs []string
+}
+
+func (r *errorRecorder) Errorf(format string, args ...interface{}) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func (r *errorRecorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+}
+
+func TestLifecycleTrackingFactory(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := &errorRecorder{}
+	f := NewLifecycleTrackingFactory(ctrl, r)
+	ctx := context.Background()
+	f.EXPECT().Nonces(gomock.Any()).Return(uint64(1), uint64(2), nil).Times(4)
+
+	// read before start
+	_, _, err := f.Nonces("io1")
+	require.NoError(err)
+	require.Equal([]string{"factory Nonces is called before the factory is started"}, r.errs)
+
+	// failed start
+	f.EXPECT().Start(gomock.Any()).Return(errors.New("failed to start")).Times(1)
+	require.Error(f.Start(ctx))
+	_, _, err = f.Nonces("io1")
+	require.NoError(err)
+	require.Len(r.errs, 2)
+
+	// read while running
+	f.EXPECT().Start(gomock.Any()).Return(nil).Times(1)
+	require.NoError(f.Start(ctx))
+	_, _, err = f.Nonces("io1")
+	require.NoError(err)
+	require.Len(r.errs, 2)
+
+	// read after stop
+	f.EXPECT().Stop(gomock.Any()).Return(nil).Times(1)
+	require.NoError(f.Stop(ctx))
+	_, _, err = f.Nonces("io1")
+	require.NoError(err)
+	require.Equal("factory Nonces is called after the factory is stopped", r.errs[2])
+}
@@ -6,9 +6,11 @@ package mock_factory
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	hash "github.com/iotexproject/go-pkgs/hash"
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/action"
 	protocol "github.com/iotexproject/iotex-core/action/protocol"
@@ -40,6 +42,96 @@ func (m *MockFactory) EXPECT() *MockFactoryMockRecorder {
 	return m.recorder
 }
 
+// AccountState mocks base method.
+func (m *MockFactory) AccountState(addr string) (*state.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountState", addr)
+	ret0, _ := ret[0].(*state.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountState indicates an expected call of AccountState.
+func (mr *MockFactoryMockRecorder) AccountState(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountState", reflect.TypeOf((*MockFactory)(nil).AccountState), addr)
+}
+
+// AccountStateAtHeight mocks base method.
+func (m *MockFactory) AccountStateAtHeight(height uint64, addr string) (*state.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountStateAtHeight", height, addr)
+	ret0, _ := ret[0].(*state.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountStateAtHeight indicates an expected call of AccountStateAtHeight.
+func (mr *MockFactoryMockRecorder) AccountStateAtHeight(height, addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountStateAtHeight", reflect.TypeOf((*MockFactory)(nil).AccountStateAtHeight), height, addr)
+}
+
+// CandidateByAddress mocks base method.
+func (m *MockFactory) CandidateByAddress(addr string) (*state.Candidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CandidateByAddress", addr)
+	ret0, _ := ret[0].(*state.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CandidateByAddress indicates an expected call of CandidateByAddress.
+func (mr *MockFactoryMockRecorder) CandidateByAddress(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CandidateByAddress", reflect.TypeOf((*MockFactory)(nil).CandidateByAddress), addr)
+}
+
+// CandidateByName mocks base method.
+func (m *MockFactory) CandidateByName(name string) (*state.Candidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CandidateByName", name)
+	ret0, _ := ret[0].(*state.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CandidateByName indicates an expected call of CandidateByName.
+func (mr *MockFactoryMockRecorder) CandidateByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CandidateByName", reflect.TypeOf((*MockFactory)(nil).CandidateByName), name)
+}
+
+// Checkpoint mocks base method.
+func (m *MockFactory) Checkpoint(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkpoint", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Checkpoint indicates an expected call of Checkpoint.
+func (mr *MockFactoryMockRecorder) Checkpoint(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkpoint", reflect.TypeOf((*MockFactory)(nil).Checkpoint), name)
+}
+
+// CommitBlock mocks base method.
+func (m *MockFactory) CommitBlock(arg0 context.Context, arg1 *block.Block) (hash.Hash256, []*action.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitBlock", arg0, arg1)
+	ret0, _ := ret[0].(hash.Hash256)
+	ret1, _ := ret[1].([]*action.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CommitBlock indicates an expected call of CommitBlock.
+func (mr *MockFactoryMockRecorder) CommitBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBlock", reflect.TypeOf((*MockFactory)(nil).CommitBlock), arg0, arg1)
+}
+
 // DeleteTipBlock mocks base method.
 func (m *MockFactory) DeleteTipBlock(arg0 context.Context, arg1 *block.Block) error {
 	m.ctrl.T.Helper()
@@ -54,6 +146,49 @@ func (mr *MockFactoryMockRecorder) DeleteTipBlock(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTipBlock", reflect.TypeOf((*MockFactory)(nil).DeleteTipBlock), arg0, arg1)
 }
 
+// ExportState mocks base method.
+func (m *MockFactory) ExportState(arg0 context.Context, arg1 uint64, arg2 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportState indicates an expected call of ExportState.
+func (mr *MockFactoryMockRecorder) ExportState(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportState", reflect.TypeOf((*MockFactory)(nil).ExportState), arg0, arg1, arg2)
+}
+
+// GetCodeByHash mocks base method.
+func (m *MockFactory) GetCodeByHash(codeHash hash.Hash256) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeByHash", codeHash)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCodeByHash indicates an expected call of GetCodeByHash.
+func (mr *MockFactoryMockRecorder) GetCodeByHash(codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeByHash", reflect.TypeOf((*MockFactory)(nil).GetCodeByHash), codeHash)
+}
+
+// GetState mocks base method.
+func (m *MockFactory) GetState(ns string, key []byte, s state.Deserializer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState", ns, key, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockFactoryMockRecorder) GetState(ns, key, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockFactory)(nil).GetState), ns, key, s)
+}
+
 // Height mocks base method.
 func (m *MockFactory) Height() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +204,34 @@ func (mr *MockFactoryMockRecorder) Height() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Height", reflect.TypeOf((*MockFactory)(nil).Height))
 }
 
+// ImportState mocks base method.
+func (m *MockFactory) ImportState(arg0 context.Context, arg1 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportState", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportState indicates an expected call of ImportState.
+func (mr *MockFactoryMockRecorder) ImportState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportState", reflect.TypeOf((*MockFactory)(nil).ImportState), arg0, arg1)
+}
+
+// IterateStates mocks base method.
+func (m *MockFactory) IterateStates(prefix []byte, fn func([]byte, *state.Account) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateStates", prefix, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateStates indicates an expected call of IterateStates.
+func (mr *MockFactoryMockRecorder) IterateStates(prefix, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateStates", reflect.TypeOf((*MockFactory)(nil).IterateStates), prefix, fn)
+}
+
 // NewBlockBuilder mocks base method.
 func (m *MockFactory) NewBlockBuilder(arg0 context.Context, arg1 actpool.ActPool, arg2 func(action.Envelope) (action.SealedEnvelope, error)) (*block.Builder, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +247,37 @@ func (mr *MockFactoryMockRecorder) NewBlockBuilder(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBlockBuilder", reflect.TypeOf((*MockFactory)(nil).NewBlockBuilder), arg0, arg1, arg2)
 }
 
+// Nonces mocks base method.
+func (m *MockFactory) Nonces(addr string) (uint64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Nonces", addr)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Nonces indicates an expected call of Nonces.
+func (mr *MockFactoryMockRecorder) Nonces(addr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nonces", reflect.TypeOf((*MockFactory)(nil).Nonces), addr)
+}
+
+// Proof mocks base method.
+func (m *MockFactory) Proof(ns string, key []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Proof", ns, key)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Proof indicates an expected call of Proof.
+func (mr *MockFactoryMockRecorder) Proof(ns, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proof", reflect.TypeOf((*MockFactory)(nil).Proof), ns, key)
+}
+
 // PutBlock mocks base method.
 func (m *MockFactory) PutBlock(arg0 context.Context, arg1 *block.Block) error {
 	m.ctrl.T.Helper()
@@ -98,6 +292,20 @@ func (mr *MockFactoryMockRecorder) PutBlock(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBlock", reflect.TypeOf((*MockFactory)(nil).PutBlock), arg0, arg1)
 }
 
+// PutState mocks base method.
+func (m *MockFactory) PutState(ns string, key []byte, s state.Serializer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutState", ns, key, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutState indicates an expected call of PutState.
+func (mr *MockFactoryMockRecorder) PutState(ns, key, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutState", reflect.TypeOf((*MockFactory)(nil).PutState), ns, key, s)
+}
+
 // ReadContractStorage mocks base method.
 func (m *MockFactory) ReadContractStorage(arg0 context.Context, arg1 address.Address, arg2 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -142,6 +350,81 @@ func (mr *MockFactoryMockRecorder) Register(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockFactory)(nil).Register), arg0)
 }
 
+// RegisteredProtocols mocks base method.
+func (m *MockFactory) RegisteredProtocols() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisteredProtocols")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// RegisteredProtocols indicates an expected call of RegisteredProtocols.
+func (mr *MockFactoryMockRecorder) RegisteredProtocols() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisteredProtocols", reflect.TypeOf((*MockFactory)(nil).RegisteredProtocols))
+}
+
+// RestoreCheckpoint mocks base method.
+func (m *MockFactory) RestoreCheckpoint(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCheckpoint", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreCheckpoint indicates an expected call of RestoreCheckpoint.
+func (mr *MockFactoryMockRecorder) RestoreCheckpoint(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCheckpoint", reflect.TypeOf((*MockFactory)(nil).RestoreCheckpoint), name)
+}
+
+// RunActions mocks base method.
+func (m *MockFactory) RunActions(arg0 context.Context, arg1 uint64, arg2 []action.SealedEnvelope) ([]*action.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunActions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*action.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunActions indicates an expected call of RunActions.
+func (mr *MockFactoryMockRecorder) RunActions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunActions", reflect.TypeOf((*MockFactory)(nil).RunActions), arg0, arg1, arg2)
+}
+
+// RunActionsWithRoot mocks base method.
+func (m *MockFactory) RunActionsWithRoot(arg0 context.Context, arg1 uint64, arg2 []action.SealedEnvelope) (hash.Hash256, []*action.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunActionsWithRoot", arg0, arg1, arg2)
+	ret0, _ := ret[0].(hash.Hash256)
+	ret1, _ := ret[1].([]*action.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunActionsWithRoot indicates an expected call of RunActionsWithRoot.
+func (mr *MockFactoryMockRecorder) RunActionsWithRoot(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunActionsWithRoot", reflect.TypeOf((*MockFactory)(nil).RunActionsWithRoot), arg0, arg1, arg2)
+}
+
+// RunBlock mocks base method.
+func (m *MockFactory) RunBlock(arg0 context.Context, arg1 *block.Block) (hash.Hash256, []*action.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunBlock", arg0, arg1)
+	ret0, _ := ret[0].(hash.Hash256)
+	ret1, _ := ret[1].([]*action.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunBlock indicates an expected call of RunBlock.
+func (mr *MockFactoryMockRecorder) RunBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunBlock", reflect.TypeOf((*MockFactory)(nil).RunBlock), arg0, arg1)
+}
+
 // SimulateExecution mocks base method.
 func (m *MockFactory) SimulateExecution(arg0 context.Context, arg1 address.Address, arg2 *action.Execution) ([]byte, *action.Receipt, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +494,21 @@ func (mr *MockFactoryMockRecorder) StateAtHeight(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateAtHeight", reflect.TypeOf((*MockFactory)(nil).StateAtHeight), varargs...)
 }
 
+// StateRoots mocks base method.
+func (m *MockFactory) StateRoots(heights []uint64) (map[uint64]hash.Hash256, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateRoots", heights)
+	ret0, _ := ret[0].(map[uint64]hash.Hash256)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateRoots indicates an expected call of StateRoots.
+func (mr *MockFactoryMockRecorder) StateRoots(heights interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateRoots", reflect.TypeOf((*MockFactory)(nil).StateRoots), heights)
+}
+
 // States mocks base method.
 func (m *MockFactory) States(arg0 ...protocol.StateOption) (uint64, state.Iterator, error) {
 	m.ctrl.T.Helper()
@@ -278,3 +576,18 @@ func (mr *MockFactoryMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockFactory)(nil).Validate), arg0, arg1)
 }
+
+// WorkingSetAtHeight mocks base method.
+func (m *MockFactory) WorkingSetAtHeight(height uint64) (protocol.WorkingSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkingSetAtHeight", height)
+	ret0, _ := ret[0].(protocol.WorkingSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkingSetAtHeight indicates an expected call of WorkingSetAtHeight.
+func (mr *MockFactoryMockRecorder) WorkingSetAtHeight(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkingSetAtHeight", reflect.TypeOf((*MockFactory)(nil).WorkingSetAtHeight), height)
+}
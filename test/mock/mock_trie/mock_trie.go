@@ -132,6 +132,21 @@ func (mr *MockTrieMockRecorder) IsEmpty() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmpty", reflect.TypeOf((*MockTrie)(nil).IsEmpty))
 }
 
+// Proof mocks base method.
+func (m *MockTrie) Proof(arg0 []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Proof", arg0)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Proof indicates an expected call of Proof.
+func (mr *MockTrieMockRecorder) Proof(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proof", reflect.TypeOf((*MockTrie)(nil).Proof), arg0)
+}
+
 // RootHash mocks base method.
 func (m *MockTrie) RootHash() ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -255,6 +270,21 @@ func (mr *MockTwoLayerTrieMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTwoLayerTrie)(nil).Get), arg0, arg1)
 }
 
+// Proof mocks base method.
+func (m *MockTwoLayerTrie) Proof(arg0, arg1 []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Proof", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Proof indicates an expected call of Proof.
+func (mr *MockTwoLayerTrieMockRecorder) Proof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proof", reflect.TypeOf((*MockTwoLayerTrie)(nil).Proof), arg0, arg1)
+}
+
 // RootHash mocks base method.
 func (m *MockTwoLayerTrie) RootHash() ([]byte, error) {
 	m.ctrl.T.Helper()